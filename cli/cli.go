@@ -0,0 +1,29 @@
+package cli
+
+// command 子命令处理函数
+type command func(args []string) error
+
+// commands 已注册的子命令
+var commands = map[string]command{
+	"restore": runRestore,
+	"undo":    runUndo,
+	"redo":    runRedo,
+	"history": runHistory,
+	"cp":      runCp,
+	"config":  runConfig,
+	"list":    runList,
+}
+
+// Run 尝试将参数作为子命令执行
+// 若第一个参数不是已知子命令，handled 返回 false，由调用方按主机参数处理；
+// 别名与子命令同名时可用 sshgo -- <alias> 连接（-- 不是子命令，其后的第一个参数为主机）
+func Run(args []string) (handled bool, err error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return false, nil
+	}
+	return true, cmd(args[1:])
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+
+	"sshgo/i18n"
+	"sshgo/settings"
+)
+
+// runConfig 查看或修改 sshgo 配置
+// sshgo config [list]            列出所有配置项的当前值
+// sshgo config path              显示配置文件路径
+// sshgo config get <key>         显示配置项的值
+// sshgo config set <key> <value> 修改配置项（列表用逗号分隔）
+// sshgo config unset <key>       恢复配置项的默认值
+func runConfig(args []string) error {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		for _, key := range settings.Keys() {
+			value, _ := settings.Get(key)
+			fmt.Printf("%s = %s\n", key, value)
+		}
+		return nil
+	case args[0] == "path" && len(args) == 1:
+		fmt.Println(settings.Path())
+		return nil
+	case args[0] == "get" && len(args) == 2:
+		value, err := settings.Get(args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+	case args[0] == "set" && len(args) == 3:
+		if err := settings.Set(args[1], args[2]); err != nil {
+			return err
+		}
+		fmt.Println(i18n.TWithArgs(i18n.SettingUpdated, args[1], args[2]))
+		return nil
+	case args[0] == "unset" && len(args) == 2:
+		if err := settings.Set(args[1], ""); err != nil {
+			return err
+		}
+		fmt.Println(i18n.TWithArgs(i18n.SettingReset, args[1]))
+		return nil
+	}
+	return fmt.Errorf("%s", i18n.T(i18n.ConfigUsage))
+}
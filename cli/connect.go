@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sshgo/i18n"
+	"sshgo/operations"
+	"sshgo/ssh"
+	"sshgo/ui"
+
+	"golang.org/x/term"
+)
+
+// Connect 连接命令行指定的主机：sshgo [ssh 选项] [user@]host[:port] [ssh 选项] [命令...]
+// host 依次按别名精确匹配、唯一前缀、模糊匹配解析为配置中的主机；
+// 匹配到多个主机时打开以 host 预先过滤的主界面（指定了用户、端口或 ssh 选项时列出候选并退出），
+// 没有匹配时视为主机地址
+func Connect(args []string) error {
+	hostArg, options, command, err := ssh.ParseCommandLine(args)
+	if err != nil {
+		return err
+	}
+	target := ssh.ParseHostArgument(hostArg)
+
+	hosts, err := ssh.ParseSSHConfig(ssh.GetSSHConfigPath())
+	if err != nil {
+		return err
+	}
+
+	host := target
+	overridden := target.User != "" || strings.Contains(hostArg, ":") || len(options) > 0
+	matches, kind := ssh.MatchHosts(hosts, target.HostName)
+	switch {
+	case len(matches) == 1:
+		host = matches[0]
+		if kind != ssh.MatchExact {
+			// 前缀或模糊匹配时提示实际连接的主机；输出到 stderr，不影响远程命令的输出
+			fmt.Fprintln(os.Stderr, i18n.TWithArgs(i18n.HostMatched, target.HostName, host.Host))
+		}
+		if target.User != "" {
+			host.User = target.User
+		}
+		if strings.Contains(hostArg, ":") {
+			host.Port = target.Port
+		}
+	case len(matches) == 0:
+		host = ssh.ApplyUserDefaults(host, hosts)
+	case len(matches) > 1:
+		// 需要执行命令或没有终端时无法交互选择；主界面中连接不会带上命令行指定的用户、端口和 ssh 选项
+		if len(command) > 0 || overridden || !term.IsTerminal(int(os.Stdin.Fd())) {
+			return ambiguousHostError(target.HostName, matches)
+		}
+		if err := ui.Run(hosts, ssh.GetSSHConfigPath(), target.HostName); err != nil {
+			return fmt.Errorf(i18n.T(i18n.ProgramError), err)
+		}
+		return nil
+	}
+
+	// 用户优先级：命令行 > 主机配置块 > 通配符块 / Host * > 本地用户名（由连接时解析）
+	host = host.ApplyOptions(options)
+	host.Command = command
+	return operations.ConnectToHost(host)
+}
+
+// ambiguousHostError 列出所有候选主机
+func ambiguousHostError(query string, matches []ssh.SSHHost) error {
+	aliases := make([]string, len(matches))
+	for i, h := range matches {
+		aliases[i] = h.Host
+	}
+	return fmt.Errorf("%s", i18n.TWithArgs(i18n.AmbiguousHost, query, strings.Join(aliases, ", ")))
+}
@@ -0,0 +1,296 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/ssh"
+	"sshgo/transfer"
+)
+
+// cpOptions sshgo cp 的选项
+type cpOptions struct {
+	recursive bool
+	quiet     bool
+	checksum  bool
+	resume    bool
+}
+
+// location 一个复制源或目标：本地路径或 [user@]host:path
+type location struct {
+	host string // 为空表示本地路径
+	path string
+}
+
+// parseLocation 解析 [user@]host:path 形式的远程路径，其他情况视为本地路径
+func parseLocation(arg string) location {
+	i := strings.Index(arg, ":")
+	// 冒号之前不能包含路径分隔符；Windows 上单个字母视为盘符
+	if i <= 0 || strings.ContainsAny(arg[:i], `/\`) || (i == 1 && runtime.GOOS == "windows") {
+		return location{path: arg}
+	}
+
+	// sftp 的相对路径以远程主目录为起点
+	p := arg[i+1:]
+	switch {
+	case p == "" || p == "~":
+		p = "."
+	case strings.HasPrefix(p, "~/"):
+		p = p[2:]
+	}
+	return location{host: arg[:i], path: p}
+}
+
+// runCp 在本地与远程主机之间复制文件，主机使用 sshgo 的配置解析
+// sshgo cp [-r] [-q] [-c] [--resume] <src>... <dst>
+func runCp(args []string) error {
+	var opts cpOptions
+	var operands []string
+	flagsDone := false
+	for _, arg := range args {
+		if flagsDone || !strings.HasPrefix(arg, "-") || arg == "-" {
+			operands = append(operands, arg)
+			continue
+		}
+		switch arg {
+		case "--":
+			flagsDone = true
+		case "-r", "-R", "--recursive":
+			opts.recursive = true
+		case "-q", "--quiet":
+			opts.quiet = true
+		case "-c", "--checksum":
+			opts.checksum = true
+		case "--resume":
+			opts.resume = true
+		default:
+			return fmt.Errorf("%s", i18n.T(i18n.CpUsage))
+		}
+	}
+	if len(operands) < 2 {
+		return fmt.Errorf("%s", i18n.T(i18n.CpUsage))
+	}
+
+	// 非终端输出时（脚本、管道）默认安静模式
+	if info, err := os.Stdout.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		opts.quiet = true
+	}
+
+	dst := parseLocation(operands[len(operands)-1])
+	var srcs []location
+	for _, arg := range operands[:len(operands)-1] {
+		srcs = append(srcs, parseLocation(arg))
+	}
+
+	// 只支持本地与单个远程主机之间的复制
+	remoteHost := dst.host
+	for _, src := range srcs {
+		if (src.host == "") == (dst.host == "") || (src.host != "" && remoteHost != "" && src.host != remoteHost) {
+			return fmt.Errorf("%s", i18n.T(i18n.CpUnsupportedDirection))
+		}
+		if src.host != "" {
+			remoteHost = src.host
+		}
+	}
+
+	host, err := ssh.ResolveHost(remoteHost)
+	if err != nil {
+		return err
+	}
+	session, err := transfer.Open(host)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	c := &copier{session: session, opts: opts, start: time.Now()}
+	if dst.host != "" {
+		err = c.upload(srcs, dst.path)
+	} else {
+		err = c.download(srcs, dst.path)
+	}
+	c.finishProgress()
+	if err != nil {
+		return err
+	}
+	if !opts.quiet {
+		fmt.Println(i18n.TWithArgs(i18n.CpSummary, c.files, transfer.FormatSize(c.bytes), time.Since(c.start).Round(time.Millisecond)))
+	}
+	return nil
+}
+
+// copier 执行一次 sshgo cp 并汇总进度
+type copier struct {
+	session    *transfer.Session
+	opts       cpOptions
+	start      time.Time
+	files      int
+	bytes      int64
+	lastReport time.Time
+	printed    bool
+}
+
+// transferOptions 构建单个源的传输选项，并在终端上显示进度
+func (c *copier) transferOptions() transfer.Options {
+	return transfer.Options{
+		Resume: c.opts.resume,
+		Verify: c.opts.checksum,
+		Progress: func(p transfer.Progress) {
+			if c.opts.quiet {
+				return
+			}
+			// 限制刷新频率，最后一个文件完成时总是刷新
+			if time.Since(c.lastReport) < 100*time.Millisecond && p.Files < p.TotalFiles {
+				return
+			}
+			c.lastReport = time.Now()
+			c.printed = true
+			fmt.Printf("\r\033[K%3.0f%%  %s / %s  (%d/%d)  %s", p.Percent()*100,
+				transfer.FormatSize(p.Done), transfer.FormatSize(p.Total), p.Files, p.TotalFiles, p.File)
+		},
+	}
+}
+
+// finishProgress 结束进度行
+func (c *copier) finishProgress() {
+	if c.printed {
+		fmt.Println()
+		c.printed = false
+	}
+}
+
+// run 执行一次传输并累计统计
+func (c *copier) run(fn func(transfer.Options) error) error {
+	var last transfer.Progress
+	opts := c.transferOptions()
+	report := opts.Progress
+	opts.Progress = func(p transfer.Progress) {
+		last = p
+		report(p)
+	}
+	err := fn(opts)
+	c.files += last.Files
+	c.bytes += last.Done
+	return err
+}
+
+// upload 上传本地文件（支持通配符）到远程路径
+func (c *copier) upload(srcs []location, dst string) error {
+	var paths []string
+	for _, src := range srcs {
+		matches, err := expandLocal(src.path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, matches...)
+	}
+
+	dstIsDir := false
+	if info, err := c.session.Stat(dst); err == nil && info.IsDir() {
+		dstIsDir = true
+	} else if len(paths) > 1 {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.CpTargetNotDirectory, dst))
+	}
+
+	for _, p := range paths {
+		if err := c.checkRecursive(os.Stat(p)); err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		err := c.run(func(opts transfer.Options) error {
+			if dstIsDir {
+				return c.session.Upload(context.Background(), p, dst, opts)
+			}
+			return c.session.UploadFile(context.Background(), p, dst, opts)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// download 下载远程文件（支持通配符）到本地路径
+func (c *copier) download(srcs []location, dst string) error {
+	var paths []string
+	for _, src := range srcs {
+		matches, err := c.expandRemote(src.path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, matches...)
+	}
+
+	dstIsDir := false
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		dstIsDir = true
+	} else if len(paths) > 1 {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.CpTargetNotDirectory, dst))
+	}
+
+	for _, p := range paths {
+		if err := c.checkRecursive(c.session.Stat(p)); err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		err := c.run(func(opts transfer.Options) error {
+			if dstIsDir {
+				return c.session.Download(context.Background(), p, dst, opts)
+			}
+			return c.session.DownloadFile(context.Background(), p, dst, opts)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRecursive 检查源是否存在，目录需要 -r
+func (c *copier) checkRecursive(info os.FileInfo, err error) error {
+	if err != nil {
+		return err
+	}
+	if info.IsDir() && !c.opts.recursive {
+		return fmt.Errorf("%s", i18n.T(i18n.CpIsDirectory))
+	}
+	return nil
+}
+
+// expandLocal 展开本地通配符（shell 未展开时，例如带引号的参数）
+func expandLocal(pattern string) ([]string, error) {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}, nil
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%s", i18n.TWithArgs(i18n.CpNoMatch, pattern))
+	}
+	return matches, nil
+}
+
+// expandRemote 展开远程通配符
+func (c *copier) expandRemote(pattern string) ([]string, error) {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}, nil
+	}
+	matches, err := c.session.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%s", i18n.TWithArgs(i18n.CpNoMatch, pattern))
+	}
+	for i, m := range matches {
+		matches[i] = path.Clean(m)
+	}
+	return matches, nil
+}
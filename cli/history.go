@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"sshgo/i18n"
+	"sshgo/operations"
+	"sshgo/ssh"
+	"sshgo/state"
+)
+
+// runHistory 显示连接历史，或按序号重新连接
+// sshgo history [-n count] [alias]
+// sshgo history [alias] -r <index>
+func runHistory(args []string) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	count := fs.Int("n", 20, "")
+	reconnect := fs.String("r", "", "")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%s", i18n.T(i18n.HistoryUsage))
+	}
+
+	alias := ""
+	if fs.NArg() > 0 {
+		alias = fs.Arg(0)
+		// 允许别名写在 -r 之前
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return fmt.Errorf("%s", i18n.T(i18n.HistoryUsage))
+		}
+	}
+
+	entries, err := state.LoadHistory(alias)
+	if err != nil {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.LoadHistoryFailed, err))
+	}
+	if len(entries) == 0 {
+		fmt.Println(i18n.T(i18n.NoHistoryFound))
+		return nil
+	}
+
+	if *reconnect != "" {
+		index, err := strconv.Atoi(*reconnect)
+		if err != nil || index < 1 || index > len(entries) {
+			return fmt.Errorf("%s", i18n.TWithArgs(i18n.InvalidHistoryIndex, *reconnect))
+		}
+		return operations.ConnectToHost(resolveHistoryHost(entries[index-1]))
+	}
+
+	for i, e := range entries {
+		if *count > 0 && i >= *count {
+			break
+		}
+		target := e.HostName
+		if e.User != "" {
+			target = e.User + "@" + target
+		}
+		fmt.Printf("%4d  %-20s %-30s %s\n", i+1, e.Alias, target, e.Summary())
+	}
+	return nil
+}
+
+// resolveHistoryHost 优先使用当前配置中的同名主机，配置已删除时按历史记录还原
+func resolveHistoryHost(e state.HistoryEntry) ssh.SSHHost {
+	hosts, err := ssh.ParseSSHConfig(ssh.GetSSHConfigPath())
+	if err == nil {
+		for _, h := range hosts {
+			if h.Host == e.Alias {
+				return h
+			}
+		}
+	}
+	return e.Host()
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"sshgo/i18n"
+	"sshgo/ssh"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// listAliasWidth 别名列的宽度
+const listAliasWidth = 24
+
+// runList 列出配置中的主机，可按搜索条件过滤，如 user:deploy tag:prod web
+// sshgo list [-q|--query <query>] [query...]
+// 以 - 开头的排除条件（如 -tag:prod）不会被当作选项
+func runList(args []string) error {
+	var terms []string
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-q" || arg == "--query":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s", i18n.T(i18n.ListUsage))
+			}
+			i++
+			terms = append(terms, args[i])
+		case strings.HasPrefix(arg, "-q=") || strings.HasPrefix(arg, "--query="):
+			terms = append(terms, arg[strings.Index(arg, "=")+1:])
+		case arg == "-h" || arg == "--help":
+			fmt.Println(i18n.T(i18n.ListUsage))
+			return nil
+		default:
+			terms = append(terms, arg)
+		}
+	}
+	query := strings.Join(terms, " ")
+
+	hosts, err := ssh.ParseSSHConfig(ssh.GetSSHConfigPath())
+	if err != nil {
+		return err
+	}
+	matches := ssh.SearchHosts(hosts, ssh.ParseQuery(query))
+	if len(matches) == 0 {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.NoHostsMatched, query))
+	}
+
+	// 输出到终端时高亮自由文本匹配到的字符
+	highlight := lipgloss.NewStyle().Bold(true).Underline(true)
+	for _, m := range matches {
+		h := hosts[m.Index]
+		matched := make(map[int]bool, len(m.MatchedIndexes))
+		for _, i := range m.MatchedIndexes {
+			matched[i] = true
+		}
+		var alias strings.Builder
+		for i, r := range h.Host {
+			if matched[i] {
+				alias.WriteString(highlight.Render(string(r)))
+			} else {
+				alias.WriteRune(r)
+			}
+		}
+		padding := strings.Repeat(" ", max(listAliasWidth-len([]rune(h.Host)), 1))
+		fmt.Printf("%s%s%-36s %s\n", alias.String(), padding, listTarget(h), listLabels(h))
+	}
+	return nil
+}
+
+// listTarget 格式化连接目标 [user@]hostname[:port]
+func listTarget(h ssh.SSHHost) string {
+	target := h.Target()
+	if h.User != "" {
+		target = h.User + "@" + target
+	}
+	if h.Port != "" && h.Port != "22" {
+		target += ":" + h.Port
+	}
+	return target
+}
+
+// listLabels 格式化分组、标签和跳板机
+func listLabels(h ssh.SSHHost) string {
+	var labels []string
+	if h.Group != "" {
+		labels = append(labels, "group:"+h.Group)
+	}
+	for _, tag := range h.Tags {
+		labels = append(labels, "tag:"+tag)
+	}
+	if h.ProxyJump != "" {
+		labels = append(labels, "via:"+h.ProxyJump)
+	}
+	return strings.Join(labels, " ")
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"sshgo/i18n"
+	"sshgo/operations"
+	"sshgo/ssh"
+)
+
+// runRestore 列出或恢复配置文件备份
+// sshgo restore [--known-hosts]          列出备份
+// sshgo restore [--known-hosts] <index>  恢复指定序号的备份
+func runRestore(args []string) error {
+	source := ssh.GetSSHConfigPath()
+	var indexArg string
+
+	for _, arg := range args {
+		switch arg {
+		case "--known-hosts":
+			source = ssh.GetKnownHostsPath()
+		case "-h", "--help":
+			fmt.Println(i18n.T(i18n.RestoreUsage))
+			return nil
+		default:
+			if indexArg != "" {
+				return fmt.Errorf("%s", i18n.T(i18n.RestoreUsage))
+			}
+			indexArg = arg
+		}
+	}
+
+	backups, err := ssh.ListBackups(source)
+	if err != nil {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.RestoreBackupFailed, err))
+	}
+	if len(backups) == 0 {
+		fmt.Println(i18n.T(i18n.NoBackupsFound))
+		return nil
+	}
+
+	// 未指定序号时仅列出备份
+	if indexArg == "" {
+		fmt.Println(i18n.TWithArgs(i18n.SelectBackupLabel, source))
+		for i, b := range backups {
+			fmt.Printf("  %2d  %s  %s\n", i+1, b.Time.Format("2006-01-02 15:04:05"), b.Path)
+		}
+		return nil
+	}
+
+	index, err := strconv.Atoi(indexArg)
+	if err != nil || index < 1 || index > len(backups) {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.InvalidBackupIndex, indexArg))
+	}
+
+	backup := backups[index-1]
+	diff, err := ssh.PreviewRestore(backup)
+	if err != nil {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.RestoreBackupFailed, err))
+	}
+	if diff == "" {
+		fmt.Println(i18n.T(i18n.NoChangesToApply))
+		return nil
+	}
+	fmt.Print(diff)
+
+	if err := operations.RestoreBackup(backup); err != nil {
+		return err
+	}
+	fmt.Println(i18n.TWithArgs(i18n.SuccessfullyRestoredBackup, source))
+	return nil
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+
+	"sshgo/i18n"
+	"sshgo/operations"
+)
+
+// runUndo 撤销最近一次配置修改；--list 时列出操作日志
+func runUndo(args []string) error {
+	if len(args) > 0 && args[0] == "--list" {
+		return printJournal()
+	}
+	return undoRedo(true)
+}
+
+// runRedo 重做最近一次被撤销的配置修改
+func runRedo(args []string) error {
+	return undoRedo(false)
+}
+
+// undoRedo 执行撤销或重做并打印对应的 diff
+func undoRedo(undo bool) error {
+	var entry *operations.JournalEntry
+	var err error
+	if undo {
+		entry, err = operations.Undo()
+	} else {
+		entry, err = operations.Redo()
+	}
+	if entry == nil {
+		return err
+	}
+
+	fmt.Print(entry.Diff(undo))
+	if undo {
+		fmt.Println(i18n.TWithArgs(i18n.UndoneChange, entry.Description))
+	} else {
+		fmt.Println(i18n.TWithArgs(i18n.RedoneChange, entry.Description))
+	}
+	return err
+}
+
+// printJournal 打印操作日志
+func printJournal() error {
+	entries, cursor, err := operations.JournalHistory()
+	if err != nil {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.JournalLoadFailed, err))
+	}
+	if len(entries) == 0 {
+		fmt.Println(i18n.T(i18n.JournalEmpty))
+		return nil
+	}
+
+	fmt.Println(i18n.T(i18n.JournalHistoryTitle))
+	for i := len(entries) - 1; i >= 0; i-- {
+		mark := " "
+		if i < cursor {
+			mark = "*"
+		}
+		e := entries[i]
+		fmt.Printf("  %s %s  %s\n", mark, e.Time.Format("2006-01-02 15:04:05"), e.Description)
+	}
+	return nil
+}
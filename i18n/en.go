@@ -38,7 +38,7 @@ var enStrings = map[StringKey]string{
 	RouteHopInfo:            "Hop %d: %s (RTT: %v)",
 	RouteHopTimeout:         "Hop %d: * (RTT: %v)",
 	RouteTraceFailed:        "Route trace failed: %v",
-	PressEscToReturn:        "esc: back",
+	PressEscToReturn:        "%s: back",
 
 	// 输入提示相关
 	EnterNewUsername: "Please enter a new username",
@@ -84,12 +84,12 @@ var enStrings = map[StringKey]string{
 	KeyCancel:  "Cancel",
 
 	// UI 消息
-	NoKeyFileConfigured:    "No key file configured for this host",
-	PressAnyKeyToReturn:    "Press any key to return",
-	EnterUsernameForHost:   "Enter username to connect to %s",
-	NoSSHHostsFound:        "No SSH host configurations found",
-	ProgramError:           "Program error: %v",
-	ConfigDeletedReloading: "Configuration deleted, reloading host list...",
+	NoKeyFileConfigured:  "No key file configured for this host",
+	PressAnyKeyToReturn:  "Press any key to return",
+	EnterUsernameForHost: "Enter username to connect to %s",
+	NoSSHHostsFound:      "No SSH host configurations found",
+	ProgramError:         "Program error: %v",
+	ConnectionEnded:      "Connection to %s ended: %v",
 
 	// SSH 连接错误
 	UsernameNotSet: "Username not set",
@@ -107,10 +107,319 @@ var enStrings = map[StringKey]string{
 	WriteKnownHostsFailed: "Failed to write known_hosts file: %v",
 
 	// 操作警告
-	DeleteHostConfigWarning: "Warning: Error deleting host config from file: %v",
 	DeleteKnownHostsWarning: "Warning: Error deleting host from known_hosts: %v",
 
 	// 其他
 	Goodbye:      "Goodbye!",
 	ConnectingTo: "Connecting to %s@%s...",
+
+	// 备份与恢复
+	BackupFailed:               "Failed to create backup: %v",
+	RestoreBackupFailed:        "Failed to restore backup: %v",
+	RestoreBackupAction:        "Restore Backup",
+	SelectBackupLabel:          "Select a backup of %s to restore",
+	NoBackupsFound:             "No backups found",
+	ConfirmRestoreBackup:       "Restore %s from the backup taken at %s?",
+	SuccessfullyRestoredBackup: "Successfully restored %s from backup",
+	DiffPreviewTitle:           "Pending changes:",
+	NoChangesToApply:           "No changes to apply",
+	DiffTruncated:              "... (%d more lines)",
+	RestoreUsage:               "Usage: sshgo restore [--known-hosts] [index]",
+	InvalidBackupIndex:         "Invalid backup index: %s",
+
+	// 操作日志（撤销/重做）
+	JournalDeleteHost:    "Delete host %s",
+	JournalModifyUser:    "Set User of %s to %s",
+	JournalModifyPort:    "Set Port of %s to %s",
+	JournalRestoreBackup: "Restore %s from backup",
+	JournalLoadFailed:    "Failed to load operation journal: %v",
+	JournalSaveFailed:    "Failed to save operation journal: %v",
+	JournalFileModified:  "%s was modified outside sshgo since this change; refusing to overwrite it",
+	NothingToUndo:        "Nothing to undo",
+	NothingToRedo:        "Nothing to redo",
+	UndoneChange:         "Undone: %s",
+	RedoneChange:         "Redone: %s",
+	KeyUndo:              "undo",
+	KeyRedo:              "redo",
+	JournalHistoryTitle:  "Operation journal (* = can be undone):",
+	JournalEmpty:         "Operation journal is empty",
+
+	// 重命名与复制主机
+	RenameHostAction:           "Rename",
+	DuplicateHostAction:        "Duplicate",
+	EnterRenameAlias:           "Enter new alias(es) for %s (space separated)",
+	EnterDuplicateAlias:        "Enter alias(es) for the copy of %s (space separated)",
+	AliasRequired:              "Alias is required",
+	AliasAlreadyExists:         "Alias '%s' already exists",
+	HostBlockNotFound:          "No Host block for '%s' in the config file",
+	HostNotEditable:            "Host '%s' is defined in %s; sshgo only edits the main config file",
+	FailedToRenameHost:         "Failed to rename host: %v",
+	FailedToDuplicateHost:      "Failed to duplicate host: %v",
+	SuccessfullyRenamedHost:    "Renamed '%s' to '%s'",
+	SuccessfullyDuplicatedHost: "Duplicated '%s' as '%s'",
+	JournalRenameHost:          "Rename host %s to %s",
+	JournalDuplicateHost:       "Duplicate host %s as %s",
+
+	// 标签与分组
+	EditTagsAction:          "Edit Tags / Group",
+	EnterHostMeta:           "Edit tags and group for %s (e.g. tags=prod,db group=eu)",
+	InvalidHostMeta:         "Invalid tag/group setting '%s', expected tags=a,b or group=name",
+	FailedToSetHostMeta:     "Failed to update tags: %v",
+	SuccessfullySetHostMeta: "Updated tags and group of '%s'",
+	JournalSetHostMeta:      "Set tags of %s to '%s'",
+	UngroupedHosts:          "Ungrouped",
+	GroupHeader:             "%s %s (%d)",
+	TagsLabel:               "Tags: %s",
+	GroupLabel:              "Group: %s",
+	KeyToggleGroup:          "expand/collapse group",
+
+	// 收藏与排序
+	SortFrecency:        "frequent",
+	SortAlphabetical:    "A-Z",
+	SortRecent:          "recent",
+	SortFileOrder:       "file order",
+	HostListTitleSorted: "%s (sort: %s)",
+	SortModeChanged:     "Sorted by %s",
+	FavoriteAdded:       "Pinned '%s' to the top",
+	FavoriteRemoved:     "Unpinned '%s'",
+	KeySort:             "sort",
+	KeyFavorite:         "pin",
+	SaveUsageFailed:     "Failed to save usage data: %v",
+	LastConnected:       "Last connected: %s (%d times)",
+
+	// 连接历史
+	HistoryTitle:        "Connection history",
+	HostHistoryTitle:    "Connection history - %s",
+	HistoryAction:       "History",
+	NoHistoryFound:      "No connection history yet",
+	LoadHistoryFailed:   "Failed to load connection history: %v",
+	HistoryEntryDesc:    "%s · %s · exit %d",
+	HistoryEntryError:   "%s · failed: %s",
+	KeyHistory:          "history",
+	KeyReconnect:        "reconnect",
+	HistoryUsage:        "Usage: sshgo history [-n count] [alias] ",
+	InvalidHistoryIndex: "Invalid history index: %s",
+
+	// 多选与批量操作
+	BatchActionTitle:          "Batch action on %d selected hosts",
+	BatchSetUserAction:        "Set User",
+	BatchSetPortAction:        "Set Port",
+	BatchSetKeyAction:         "Set IdentityFile",
+	BatchDeleteAction:         "Delete Configurations",
+	BatchCheckAction:          "Check Reachability",
+	BatchExportAction:         "Export",
+	BatchClearAction:          "Clear Selection",
+	EnterBatchValue:           "Enter %s for %d hosts",
+	EnterExportPath:           "Export %d hosts to file",
+	ConfirmBatchDelete:        "Delete the configuration of %d hosts: %s?",
+	SuccessfullyBatchSet:      "Set %s to '%s' on %d hosts",
+	SuccessfullyBatchDeleted:  "Deleted the configuration of %d hosts",
+	SuccessfullyExportedHosts: "Exported %d hosts to %s",
+	FailedToBatchEdit:         "Batch edit failed: %v",
+	FailedToExportHosts:       "Failed to export hosts: %v",
+	ExportFileExists:          "Failed to export hosts: %s already exists",
+	JournalBatchSet:           "Set %s to %s on %d hosts",
+	JournalBatchDelete:        "Delete %d hosts",
+	ReachabilityTitle:         "Reachability of %d hosts",
+	ReachabilityOK:            "✓ %-24s %s:%s  %v",
+	ReachabilityFailed:        "✗ %-24s %s:%s  %v",
+	ReachabilityPending:       "… %-24s %s:%s",
+	ReachabilitySummary:       "%d reachable, %d unreachable, %d pending",
+	SelectedCount:             "%d selected",
+	KeyMark:                   "mark",
+	KeyMarkAll:                "mark all",
+
+	// 端口校验
+	InvalidPortNumber: "Invalid port number: %s",
+
+	// 并发执行远程命令
+	BatchRunAction:             "Run Command",
+	EnterRunCommand:            "Command to run on %d hosts",
+	RunTitle:                   "Run on %d hosts: %s",
+	RunStatusPending:           "pending",
+	RunStatusRunning:           "running",
+	RunStatusOK:                "ok",
+	RunStatusExit:              "exit %d",
+	RunStatusError:             "error",
+	RunStatusCancelled:         "cancelled",
+	RunSummary:                 "%d ok, %d failed, %d running, %d pending",
+	RunHelpRunning:             "%s: select host • %s: save output • %s: cancel",
+	RunHelpDone:                "%s: select host • %s: save output • %s: back",
+	RunNoOutput:                "(no output)",
+	EnterRunOutputPath:         "Save combined output of %d hosts to file",
+	SuccessfullySavedRunOutput: "Saved output of %d hosts to %s",
+	FailedToSaveRunOutput:      "Failed to save output: %v",
+	RunOutputFileExists:        "Failed to save output: %s already exists",
+	RemoteCommandRequired:      "Command is required",
+
+	// SFTP 文件浏览与传输
+	SFTPAction:            "File Browser (SFTP)",
+	SFTPConnecting:        "Opening SFTP session to %s...",
+	SFTPConnectFailed:     "Failed to open SFTP session to %s: %v",
+	TransferFailed:        "Failed to transfer %s: %v",
+	SFTPTitle:             "SFTP - %s",
+	SFTPLocalPane:         "Local: %s",
+	SFTPRemotePane:        "%s: %s",
+	SFTPEmptyDir:          "(empty)",
+	SFTPHelp:              "%s: switch pane • %s: open • %s: parent • %s: copy to other pane • %s: resume • %s: quit",
+	SFTPHelpTransfer:      "%s: cancel transfer",
+	SFTPUploaded:          "Uploaded %s to %s",
+	SFTPDownloaded:        "Downloaded %s to %s",
+	SFTPTransferCancelled: "Transfer cancelled, press %s to resume",
+	SFTPTransferRetryHint: "%v (press %s to resume)",
+	SFTPNothingToResume:   "Nothing to resume",
+
+	// sshgo cp 文件复制
+	ProxyJumpLabel:         "ProxyJump: %s",
+	ChecksumMismatch:       "checksum mismatch (source %s, destination %s)",
+	CpUsage:                "Usage: sshgo cp [-r] [-q] [-c|--checksum] [--resume] <src>... <dst>  (remote paths: [user@]host:path)",
+	CpUnsupportedDirection: "Copies must be between local paths and a single remote host",
+	CpTargetNotDirectory:   "Target %s is not a directory",
+	CpIsDirectory:          "is a directory (use -r to copy recursively)",
+	CpNoMatch:              "No match: %s",
+	CpSummary:              "Copied %d files (%s) in %v",
+
+	// tmux/screen 启动
+	ConnectNewWindowAction: "Connect in New Window",
+	BatchTiledAction:       "Connect in Tiled Panes (synchronized input)",
+	InvalidLaunchMode:      "Invalid launch mode: %s (expected current, window or pane)",
+	NotInMultiplexer:       "Not running inside tmux or screen",
+	NoHostsSelected:        "No hosts selected",
+	TmuxRequired:           "Tiled panes require tmux to be installed",
+	OpenedInNewWindow:      "Opened %s in a new window",
+	OpenedTiledPanes:       "Opened %d hosts in tiled panes with synchronized input",
+
+	// 会话录制与回放
+	ConnectRecordAction:      "Connect and Record Session",
+	RecordingsAction:         "Session Recordings",
+	RecordingTo:              "Recording session to %s",
+	RecordingUnsupported:     "Session recording is not supported on this platform",
+	RecordingsTitle:          "Session Recordings (speed %sx)",
+	HostRecordingsTitle:      "Session Recordings: %s (speed %sx)",
+	NoRecordingsFound:        "No session recordings found",
+	LoadRecordingsFailed:     "Failed to load recordings: %v",
+	PlaybackFailed:           "Playback failed: %v",
+	PlaybackTerminalTooSmall: "Recorded at %dx%d, terminal is %dx%d; output may wrap",
+	PlaybackFinished:         "Playback finished, press any key to return",
+	KeyRecordings:            "recordings",
+	KeyPlay:                  "play",
+	KeySpeed:                 "speed",
+
+	// 远程命令
+	SetRemoteCommandAction:           "Set Remote Command",
+	ConnectRunAction:                 "Connect and Run...",
+	EnterRemoteCommand:               "Remote command to run on every connection to %s (e.g. sudo -i, tmux new -A -s main)",
+	EnterConnectRunCommand:           "Command to run after connecting to %s",
+	RemoteCommandHint:                "Leave empty to clear. Saved as RemoteCommand; a pseudo-terminal is requested unless RequestTTY is set.",
+	SuccessfullySetRemoteCommand:     "Set remote command of '%s' to '%s'",
+	SuccessfullyClearedRemoteCommand: "Cleared remote command of '%s'",
+	FailedToSetRemoteCommand:         "Failed to set remote command: %v",
+	JournalSetRemoteCommand:          "Set remote command of %s to '%s'",
+	JournalClearRemoteCommand:        "Clear remote command of %s",
+	InvalidRequestTTY:                "Invalid RequestTTY value: %s (expected yes, no, force or auto)",
+	RemoteCommandLabel:               "Remote command: %s",
+	RequestTTYLabel:                  "RequestTTY: %s",
+
+	// 命令行 ssh 选项
+	OptionRequiresArgument: "Option %s requires an argument",
+	HostArgumentRequired:   "Host argument is required (usage: sshgo [ssh options] [user@]host[:port] [command...])",
+
+	// 命令行主机匹配
+	AmbiguousHost: "'%s' matches multiple hosts: %s",
+	HostMatched:   "'%s' matched host %s",
+
+	// sshgo 配置文件
+	SettingsLoadFailed:   "Failed to load settings from %s: %v",
+	SettingUnknownKey:    "Unknown setting: %s",
+	SettingInvalidChoice: "Invalid value for %s: %s (expected one of %s)",
+	SettingTooSmall:      "Invalid value for %s: %d (must be at least %d)",
+	SettingNotPositive:   "Invalid value for %s: %v (must be positive)",
+	SettingInvalidValue:  "Invalid value for %s: %s (%v)",
+	SettingUpdated:       "Set %s = %s",
+	SettingReset:         "Reset %s to default",
+	ConfigUsage:          "Usage: sshgo config [list | path | get <key> | set <key> <value> | unset <key>]",
+
+	// 按键绑定
+	SettingKeyConflict: "Key %s is bound to both keys.%s and keys.%s",
+	KeyHelp:            "help",
+	KeyUp:              "up",
+	KeyDown:            "down",
+	KeyPageUp:          "prev page",
+	KeyPageDown:        "next page",
+	KeyTop:             "go to start",
+	KeyBottom:          "go to end",
+	KeySpeedUp:         "faster",
+	KeySpeedDown:       "slower",
+	KeySaveOutput:      "save output",
+	KeySwitchPane:      "switch pane",
+	KeyOpen:            "open directory",
+	KeyParent:          "parent directory",
+	KeyCopy:            "copy to other pane",
+	KeyResume:          "resume transfer",
+	KeyForceQuit:       "quit from any screen",
+	KeyHelpTitle:       "Key bindings (profile: %s)",
+	KeyHelpGeneral:     "General",
+	KeyHelpNavigation:  "Navigation",
+	KeyHelpDialogs:     "Confirmation",
+	KeyHelpHostList:    "Host list",
+	KeyHelpRecordings:  "Recordings",
+	KeyHelpRun:         "Run command",
+	KeyHelpFiles:       "File browser",
+	KeyHelpFooter:      "Text fields use %s/%s • press any key to close",
+
+	// 鼠标
+	KeyHelpMouse:     "Mouse",
+	MouseClick:       "select host, toggle group or run menu entry",
+	MouseDoubleClick: "connect to host",
+	MouseWheel:       "move up/down",
+
+	// 命令面板
+	KeyPalette:            "command palette",
+	PaletteTitle:          "Command palette",
+	PalettePlaceholder:    "Type to search commands",
+	PaletteNoMatches:      "No matching commands",
+	PaletteHelp:           "↑/↓: select • %s: run • %s: close",
+	CommandReloadConfig:   "Reload SSH config",
+	ConfigReloadedAll:     "SSH config reloaded",
+	CommandSwitchLanguage: "Switch language (中文 / English)",
+	LanguageSwitched:      "Switched to English",
+	CommandOpenHistory:    "Open connection history",
+	CommandOpenRecordings: "Open session recordings",
+	CommandCycleSort:      "Change sort order",
+	CommandToggleFavorite: "Pin / unpin selected host",
+	CommandMarkAll:        "Select / deselect all hosts",
+	CommandClearMarks:     "Clear selection",
+	CommandBatchMenu:      "Batch actions on selected hosts",
+	CommandUndo:           "Undo last config change",
+	CommandRedo:           "Redo last undone change",
+	CommandShowKeys:       "Show key bindings",
+	CommandQuit:           "Quit sshgo",
+
+	// 界面主题
+	SettingInvalidColor: "Invalid color for %s: %q (use #rrggbb, #rgb or a terminal color number 0-255)",
+
+	// 配置文件变化
+	ConfigModifiedExternally: "%s was modified outside sshgo after it was loaded",
+	ConfigReloaded:           "%s changed on disk; host list reloaded",
+	ConfigConflictTitle:      "Config file changed on disk",
+	ConfigConflictPrompt:     "%s was modified by another program after sshgo loaded it.\nMerge applies your change on top of the current file; abort discards it and reloads the host list.",
+	ConfigConflictHelp:       "%s: merge • %s/%s: abort",
+	ConfigConflictAborted:    "Change aborted; host list reloaded",
+	SettingNegative:          "Invalid value for %s: %v (must not be negative)",
+
+	// 主机预览
+	PreviewConfigTitle:    "Effective config (ssh -G)",
+	PreviewLoading:        "Loading...",
+	PreviewChecking:       "Reachability: checking...",
+	PreviewReachable:      "Reachability: reachable (%s)",
+	PreviewUnreachable:    "Reachability: unreachable (%v)",
+	PreviewNeverConnected: "Last connected: never",
+	PreviewKeyInfo:        "Key: %s %s (%s)",
+	PreviewKeyNoPublic:    "Key: %s (no .pub file)",
+	PreviewKeyMissing:     "Key: %s (not found)",
+	PreviewPattern:        "Wildcard block: its settings apply to every matching host",
+
+	// 主机搜索
+	ListUsage:      "Usage: sshgo list [-q|--query <query>] [query...]  (fields: host: user: port: tag: group: key: via:, prefix - to exclude)",
+	NoHostsMatched: "No hosts match %q",
 }
@@ -62,6 +62,11 @@ func SetLanguage(lang Language) {
 	}
 }
 
+// CurrentLanguage 返回当前界面语言
+func CurrentLanguage() Language {
+	return currentLanguage
+}
+
 // T 获取指定键的翻译字符串
 func T(key StringKey) string {
 	var translations map[StringKey]string
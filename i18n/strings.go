@@ -87,12 +87,12 @@ const (
 	KeyCancel  StringKey = "key_cancel"
 
 	// UI 消息
-	NoKeyFileConfigured   StringKey = "no_key_file_configured"
-	PressAnyKeyToReturn   StringKey = "press_any_key_to_return"
-	EnterUsernameForHost  StringKey = "enter_username_for_host"
-	NoSSHHostsFound       StringKey = "no_ssh_hosts_found"
-	ProgramError          StringKey = "program_error"
-	ConfigDeletedReloading StringKey = "config_deleted_reloading"
+	NoKeyFileConfigured  StringKey = "no_key_file_configured"
+	PressAnyKeyToReturn  StringKey = "press_any_key_to_return"
+	EnterUsernameForHost StringKey = "enter_username_for_host"
+	NoSSHHostsFound      StringKey = "no_ssh_hosts_found"
+	ProgramError         StringKey = "program_error"
+	ConnectionEnded      StringKey = "connection_ended"
 
 	// SSH 连接错误
 	UsernameNotSet StringKey = "username_not_set"
@@ -110,10 +110,319 @@ const (
 	WriteKnownHostsFailed StringKey = "write_known_hosts_failed"
 
 	// 操作警告
-	DeleteHostConfigWarning  StringKey = "delete_host_config_warning"
 	DeleteKnownHostsWarning  StringKey = "delete_known_hosts_warning"
 
 	// 其他
 	Goodbye      StringKey = "goodbye"
 	ConnectingTo StringKey = "connecting_to"
+
+	// 备份与恢复
+	BackupFailed               StringKey = "backup_failed"
+	RestoreBackupFailed        StringKey = "restore_backup_failed"
+	RestoreBackupAction        StringKey = "restore_backup_action"
+	SelectBackupLabel          StringKey = "select_backup_label"
+	NoBackupsFound             StringKey = "no_backups_found"
+	ConfirmRestoreBackup       StringKey = "confirm_restore_backup"
+	SuccessfullyRestoredBackup StringKey = "successfully_restored_backup"
+	DiffPreviewTitle           StringKey = "diff_preview_title"
+	NoChangesToApply           StringKey = "no_changes_to_apply"
+	DiffTruncated              StringKey = "diff_truncated"
+	RestoreUsage               StringKey = "restore_usage"
+	InvalidBackupIndex         StringKey = "invalid_backup_index"
+
+	// 操作日志（撤销/重做）
+	JournalDeleteHost    StringKey = "journal_delete_host"
+	JournalModifyUser    StringKey = "journal_modify_user"
+	JournalModifyPort    StringKey = "journal_modify_port"
+	JournalRestoreBackup StringKey = "journal_restore_backup"
+	JournalLoadFailed    StringKey = "journal_load_failed"
+	JournalSaveFailed    StringKey = "journal_save_failed"
+	JournalFileModified  StringKey = "journal_file_modified"
+	NothingToUndo        StringKey = "nothing_to_undo"
+	NothingToRedo        StringKey = "nothing_to_redo"
+	UndoneChange         StringKey = "undone_change"
+	RedoneChange         StringKey = "redone_change"
+	KeyUndo              StringKey = "key_undo"
+	KeyRedo              StringKey = "key_redo"
+	JournalHistoryTitle  StringKey = "journal_history_title"
+	JournalEmpty         StringKey = "journal_empty"
+
+	// 重命名与复制主机
+	RenameHostAction           StringKey = "rename_host_action"
+	DuplicateHostAction        StringKey = "duplicate_host_action"
+	EnterRenameAlias           StringKey = "enter_rename_alias"
+	EnterDuplicateAlias        StringKey = "enter_duplicate_alias"
+	AliasRequired              StringKey = "alias_required"
+	AliasAlreadyExists         StringKey = "alias_already_exists"
+	HostBlockNotFound          StringKey = "host_block_not_found"
+	HostNotEditable            StringKey = "host_not_editable"
+	FailedToRenameHost         StringKey = "failed_to_rename_host"
+	FailedToDuplicateHost      StringKey = "failed_to_duplicate_host"
+	SuccessfullyRenamedHost    StringKey = "successfully_renamed_host"
+	SuccessfullyDuplicatedHost StringKey = "successfully_duplicated_host"
+	JournalRenameHost          StringKey = "journal_rename_host"
+	JournalDuplicateHost       StringKey = "journal_duplicate_host"
+
+	// 标签与分组
+	EditTagsAction          StringKey = "edit_tags_action"
+	EnterHostMeta           StringKey = "enter_host_meta"
+	InvalidHostMeta         StringKey = "invalid_host_meta"
+	FailedToSetHostMeta     StringKey = "failed_to_set_host_meta"
+	SuccessfullySetHostMeta StringKey = "successfully_set_host_meta"
+	JournalSetHostMeta      StringKey = "journal_set_host_meta"
+	UngroupedHosts          StringKey = "ungrouped_hosts"
+	GroupHeader             StringKey = "group_header"
+	TagsLabel               StringKey = "tags_label"
+	GroupLabel              StringKey = "group_label"
+	KeyToggleGroup          StringKey = "key_toggle_group"
+
+	// 收藏与排序
+	SortFrecency        StringKey = "sort_frecency"
+	SortAlphabetical    StringKey = "sort_alphabetical"
+	SortRecent          StringKey = "sort_recent"
+	SortFileOrder       StringKey = "sort_file_order"
+	HostListTitleSorted StringKey = "host_list_title_sorted"
+	SortModeChanged     StringKey = "sort_mode_changed"
+	FavoriteAdded       StringKey = "favorite_added"
+	FavoriteRemoved     StringKey = "favorite_removed"
+	KeySort             StringKey = "key_sort"
+	KeyFavorite         StringKey = "key_favorite"
+	SaveUsageFailed     StringKey = "save_usage_failed"
+	LastConnected       StringKey = "last_connected"
+
+	// 连接历史
+	HistoryTitle        StringKey = "history_title"
+	HostHistoryTitle    StringKey = "host_history_title"
+	HistoryAction       StringKey = "history_action"
+	NoHistoryFound      StringKey = "no_history_found"
+	LoadHistoryFailed   StringKey = "load_history_failed"
+	HistoryEntryDesc    StringKey = "history_entry_desc"
+	HistoryEntryError   StringKey = "history_entry_error"
+	KeyHistory          StringKey = "key_history"
+	KeyReconnect        StringKey = "key_reconnect"
+	HistoryUsage        StringKey = "history_usage"
+	InvalidHistoryIndex StringKey = "invalid_history_index"
+
+	// 多选与批量操作
+	BatchActionTitle          StringKey = "batch_action_title"
+	BatchSetUserAction        StringKey = "batch_set_user_action"
+	BatchSetPortAction        StringKey = "batch_set_port_action"
+	BatchSetKeyAction         StringKey = "batch_set_key_action"
+	BatchDeleteAction         StringKey = "batch_delete_action"
+	BatchCheckAction          StringKey = "batch_check_action"
+	BatchExportAction         StringKey = "batch_export_action"
+	BatchClearAction          StringKey = "batch_clear_action"
+	EnterBatchValue           StringKey = "enter_batch_value"
+	EnterExportPath           StringKey = "enter_export_path"
+	ConfirmBatchDelete        StringKey = "confirm_batch_delete"
+	SuccessfullyBatchSet      StringKey = "successfully_batch_set"
+	SuccessfullyBatchDeleted  StringKey = "successfully_batch_deleted"
+	SuccessfullyExportedHosts StringKey = "successfully_exported_hosts"
+	FailedToBatchEdit         StringKey = "failed_to_batch_edit"
+	FailedToExportHosts       StringKey = "failed_to_export_hosts"
+	ExportFileExists          StringKey = "export_file_exists"
+	JournalBatchSet           StringKey = "journal_batch_set"
+	JournalBatchDelete        StringKey = "journal_batch_delete"
+	ReachabilityTitle         StringKey = "reachability_title"
+	ReachabilityOK            StringKey = "reachability_ok"
+	ReachabilityFailed        StringKey = "reachability_failed"
+	ReachabilityPending       StringKey = "reachability_pending"
+	ReachabilitySummary       StringKey = "reachability_summary"
+	SelectedCount             StringKey = "selected_count"
+	KeyMark                   StringKey = "key_mark"
+	KeyMarkAll                StringKey = "key_mark_all"
+
+	// 端口校验
+	InvalidPortNumber StringKey = "invalid_port_number"
+
+	// 并发执行远程命令
+	BatchRunAction             StringKey = "batch_run_action"
+	EnterRunCommand            StringKey = "enter_run_command"
+	RunTitle                   StringKey = "run_title"
+	RunStatusPending           StringKey = "run_status_pending"
+	RunStatusRunning           StringKey = "run_status_running"
+	RunStatusOK                StringKey = "run_status_ok"
+	RunStatusExit              StringKey = "run_status_exit"
+	RunStatusError             StringKey = "run_status_error"
+	RunStatusCancelled         StringKey = "run_status_cancelled"
+	RunSummary                 StringKey = "run_summary"
+	RunHelpRunning             StringKey = "run_help_running"
+	RunHelpDone                StringKey = "run_help_done"
+	RunNoOutput                StringKey = "run_no_output"
+	EnterRunOutputPath         StringKey = "enter_run_output_path"
+	SuccessfullySavedRunOutput StringKey = "successfully_saved_run_output"
+	FailedToSaveRunOutput      StringKey = "failed_to_save_run_output"
+	RunOutputFileExists        StringKey = "run_output_file_exists"
+	RemoteCommandRequired      StringKey = "remote_command_required"
+
+	// SFTP 文件浏览与传输
+	SFTPAction            StringKey = "sftp_action"
+	SFTPConnecting        StringKey = "sftp_connecting"
+	SFTPConnectFailed     StringKey = "sftp_connect_failed"
+	TransferFailed        StringKey = "transfer_failed"
+	SFTPTitle             StringKey = "sftp_title"
+	SFTPLocalPane         StringKey = "sftp_local_pane"
+	SFTPRemotePane        StringKey = "sftp_remote_pane"
+	SFTPEmptyDir          StringKey = "sftp_empty_dir"
+	SFTPHelp              StringKey = "sftp_help"
+	SFTPHelpTransfer      StringKey = "sftp_help_transfer"
+	SFTPUploaded          StringKey = "sftp_uploaded"
+	SFTPDownloaded        StringKey = "sftp_downloaded"
+	SFTPTransferCancelled StringKey = "sftp_transfer_cancelled"
+	SFTPTransferRetryHint StringKey = "sftp_transfer_retry_hint"
+	SFTPNothingToResume   StringKey = "sftp_nothing_to_resume"
+
+	// sshgo cp 文件复制
+	ProxyJumpLabel         StringKey = "proxy_jump_label"
+	ChecksumMismatch       StringKey = "checksum_mismatch"
+	CpUsage                StringKey = "cp_usage"
+	CpUnsupportedDirection StringKey = "cp_unsupported_direction"
+	CpTargetNotDirectory   StringKey = "cp_target_not_directory"
+	CpIsDirectory          StringKey = "cp_is_directory"
+	CpNoMatch              StringKey = "cp_no_match"
+	CpSummary              StringKey = "cp_summary"
+
+	// tmux/screen 启动
+	ConnectNewWindowAction StringKey = "connect_new_window_action"
+	BatchTiledAction       StringKey = "batch_tiled_action"
+	InvalidLaunchMode      StringKey = "invalid_launch_mode"
+	NotInMultiplexer       StringKey = "not_in_multiplexer"
+	NoHostsSelected        StringKey = "no_hosts_selected"
+	TmuxRequired           StringKey = "tmux_required"
+	OpenedInNewWindow      StringKey = "opened_in_new_window"
+	OpenedTiledPanes       StringKey = "opened_tiled_panes"
+
+	// 会话录制与回放
+	ConnectRecordAction      StringKey = "connect_record_action"
+	RecordingsAction         StringKey = "recordings_action"
+	RecordingTo              StringKey = "recording_to"
+	RecordingUnsupported     StringKey = "recording_unsupported"
+	RecordingsTitle          StringKey = "recordings_title"
+	HostRecordingsTitle      StringKey = "host_recordings_title"
+	NoRecordingsFound        StringKey = "no_recordings_found"
+	LoadRecordingsFailed     StringKey = "load_recordings_failed"
+	PlaybackFailed           StringKey = "playback_failed"
+	PlaybackTerminalTooSmall StringKey = "playback_terminal_too_small"
+	PlaybackFinished         StringKey = "playback_finished"
+	KeyRecordings            StringKey = "key_recordings"
+	KeyPlay                  StringKey = "key_play"
+	KeySpeed                 StringKey = "key_speed"
+
+	// 远程命令
+	SetRemoteCommandAction           StringKey = "set_remote_command_action"
+	ConnectRunAction                 StringKey = "connect_run_action"
+	EnterRemoteCommand               StringKey = "enter_remote_command"
+	EnterConnectRunCommand           StringKey = "enter_connect_run_command"
+	RemoteCommandHint                StringKey = "remote_command_hint"
+	SuccessfullySetRemoteCommand     StringKey = "successfully_set_remote_command"
+	SuccessfullyClearedRemoteCommand StringKey = "successfully_cleared_remote_command"
+	FailedToSetRemoteCommand         StringKey = "failed_to_set_remote_command"
+	JournalSetRemoteCommand          StringKey = "journal_set_remote_command"
+	JournalClearRemoteCommand        StringKey = "journal_clear_remote_command"
+	InvalidRequestTTY                StringKey = "invalid_request_tty"
+	RemoteCommandLabel               StringKey = "remote_command_label"
+	RequestTTYLabel                  StringKey = "request_tty_label"
+
+	// 命令行 ssh 选项
+	OptionRequiresArgument StringKey = "option_requires_argument"
+	HostArgumentRequired   StringKey = "host_argument_required"
+
+	// 命令行主机匹配
+	AmbiguousHost StringKey = "ambiguous_host"
+	HostMatched   StringKey = "host_matched"
+
+	// sshgo 配置文件
+	SettingsLoadFailed   StringKey = "settings_load_failed"
+	SettingUnknownKey    StringKey = "setting_unknown_key"
+	SettingInvalidChoice StringKey = "setting_invalid_choice"
+	SettingTooSmall      StringKey = "setting_too_small"
+	SettingNotPositive   StringKey = "setting_not_positive"
+	SettingInvalidValue  StringKey = "setting_invalid_value"
+	SettingUpdated       StringKey = "setting_updated"
+	SettingReset         StringKey = "setting_reset"
+	ConfigUsage          StringKey = "config_usage"
+
+	// 按键绑定
+	SettingKeyConflict StringKey = "setting_key_conflict"
+	KeyHelp            StringKey = "key_help"
+	KeyUp              StringKey = "key_up"
+	KeyDown            StringKey = "key_down"
+	KeyPageUp          StringKey = "key_page_up"
+	KeyPageDown        StringKey = "key_page_down"
+	KeyTop             StringKey = "key_top"
+	KeyBottom          StringKey = "key_bottom"
+	KeySpeedUp         StringKey = "key_speed_up"
+	KeySpeedDown       StringKey = "key_speed_down"
+	KeySaveOutput      StringKey = "key_save_output"
+	KeySwitchPane      StringKey = "key_switch_pane"
+	KeyOpen            StringKey = "key_open"
+	KeyParent          StringKey = "key_parent"
+	KeyCopy            StringKey = "key_copy"
+	KeyResume          StringKey = "key_resume"
+	KeyForceQuit       StringKey = "key_force_quit"
+	KeyHelpTitle       StringKey = "key_help_title"
+	KeyHelpGeneral     StringKey = "key_help_general"
+	KeyHelpNavigation  StringKey = "key_help_navigation"
+	KeyHelpDialogs     StringKey = "key_help_dialogs"
+	KeyHelpHostList    StringKey = "key_help_host_list"
+	KeyHelpRecordings  StringKey = "key_help_recordings"
+	KeyHelpRun         StringKey = "key_help_run"
+	KeyHelpFiles       StringKey = "key_help_files"
+	KeyHelpFooter      StringKey = "key_help_footer"
+
+	// 鼠标
+	KeyHelpMouse     StringKey = "key_help_mouse"
+	MouseClick       StringKey = "mouse_click"
+	MouseDoubleClick StringKey = "mouse_double_click"
+	MouseWheel       StringKey = "mouse_wheel"
+
+	// 命令面板
+	KeyPalette            StringKey = "key_palette"
+	PaletteTitle          StringKey = "palette_title"
+	PalettePlaceholder    StringKey = "palette_placeholder"
+	PaletteNoMatches      StringKey = "palette_no_matches"
+	PaletteHelp           StringKey = "palette_help"
+	CommandReloadConfig   StringKey = "command_reload_config"
+	ConfigReloadedAll     StringKey = "config_reloaded_all"
+	CommandSwitchLanguage StringKey = "command_switch_language"
+	LanguageSwitched      StringKey = "language_switched"
+	CommandOpenHistory    StringKey = "command_open_history"
+	CommandOpenRecordings StringKey = "command_open_recordings"
+	CommandCycleSort      StringKey = "command_cycle_sort"
+	CommandToggleFavorite StringKey = "command_toggle_favorite"
+	CommandMarkAll        StringKey = "command_mark_all"
+	CommandClearMarks     StringKey = "command_clear_marks"
+	CommandBatchMenu      StringKey = "command_batch_menu"
+	CommandUndo           StringKey = "command_undo"
+	CommandRedo           StringKey = "command_redo"
+	CommandShowKeys       StringKey = "command_show_keys"
+	CommandQuit           StringKey = "command_quit"
+
+	// 界面主题
+	SettingInvalidColor StringKey = "setting_invalid_color"
+
+	// 配置文件变化
+	ConfigModifiedExternally StringKey = "config_modified_externally"
+	ConfigReloaded           StringKey = "config_reloaded"
+	ConfigConflictTitle      StringKey = "config_conflict_title"
+	ConfigConflictPrompt     StringKey = "config_conflict_prompt"
+	ConfigConflictHelp       StringKey = "config_conflict_help"
+	ConfigConflictAborted    StringKey = "config_conflict_aborted"
+	SettingNegative          StringKey = "setting_negative"
+
+	// 主机预览
+	PreviewConfigTitle    StringKey = "preview_config_title"
+	PreviewLoading        StringKey = "preview_loading"
+	PreviewChecking       StringKey = "preview_checking"
+	PreviewReachable      StringKey = "preview_reachable"
+	PreviewUnreachable    StringKey = "preview_unreachable"
+	PreviewNeverConnected StringKey = "preview_never_connected"
+	PreviewKeyInfo        StringKey = "preview_key_info"
+	PreviewKeyNoPublic    StringKey = "preview_key_no_public"
+	PreviewKeyMissing     StringKey = "preview_key_missing"
+	PreviewPattern        StringKey = "preview_pattern"
+
+	// 主机搜索
+	ListUsage      StringKey = "list_usage"
+	NoHostsMatched StringKey = "no_hosts_matched"
 )
@@ -38,7 +38,7 @@ var zhStrings = map[StringKey]string{
 	RouteHopInfo:            "跳点 %d: %s (RTT: %v)",
 	RouteHopTimeout:         "跳点 %d: * (RTT: %v)",
 	RouteTraceFailed:        "路由追踪失败: %v",
-	PressEscToReturn:        "esc: 返回",
+	PressEscToReturn:        "%s: 返回",
 
 	// 输入提示相关
 	EnterNewUsername: "请输入新用户名",
@@ -84,12 +84,12 @@ var zhStrings = map[StringKey]string{
 	KeyCancel:  "取消",
 
 	// UI 消息
-	NoKeyFileConfigured:    "该主机没有配置密钥文件",
-	PressAnyKeyToReturn:    "按任意键返回",
-	EnterUsernameForHost:   "输入用户名连接到 %s",
-	NoSSHHostsFound:        "未找到SSH主机配置",
-	ProgramError:           "程序运行错误: %v",
-	ConfigDeletedReloading: "配置已删除，重新加载主机列表...",
+	NoKeyFileConfigured:  "该主机没有配置密钥文件",
+	PressAnyKeyToReturn:  "按任意键返回",
+	EnterUsernameForHost: "输入用户名连接到 %s",
+	NoSSHHostsFound:      "未找到SSH主机配置",
+	ProgramError:         "程序运行错误: %v",
+	ConnectionEnded:      "与 %s 的连接已结束: %v",
 
 	// SSH 连接错误
 	UsernameNotSet: "用户名未设置",
@@ -107,10 +107,319 @@ var zhStrings = map[StringKey]string{
 	WriteKnownHostsFailed: "写入known_hosts文件失败: %v",
 
 	// 操作警告
-	DeleteHostConfigWarning: "警告: 从配置文件中删除主机配置时出错: %v",
 	DeleteKnownHostsWarning: "警告: 从known_hosts文件中删除主机记录时出错: %v",
 
 	// 其他
 	Goodbye:      "再见!",
 	ConnectingTo: "正在连接到 %s@%s...",
+
+	// 备份与恢复
+	BackupFailed:               "创建备份失败: %v",
+	RestoreBackupFailed:        "恢复备份失败: %v",
+	RestoreBackupAction:        "恢复备份",
+	SelectBackupLabel:          "选择要恢复的 %s 备份",
+	NoBackupsFound:             "没有找到备份",
+	ConfirmRestoreBackup:       "确定要用 %[2]s 创建的备份恢复 %[1]s 吗?",
+	SuccessfullyRestoredBackup: "成功从备份恢复 %s",
+	DiffPreviewTitle:           "待应用的更改:",
+	NoChangesToApply:           "没有需要应用的更改",
+	DiffTruncated:              "... (还有 %d 行)",
+	RestoreUsage:               "用法: sshgo restore [--known-hosts] [序号]",
+	InvalidBackupIndex:         "无效的备份序号: %s",
+
+	// 操作日志（撤销/重做）
+	JournalDeleteHost:    "删除主机 %s",
+	JournalModifyUser:    "将 %s 的用户设置为 %s",
+	JournalModifyPort:    "将 %s 的端口设置为 %s",
+	JournalRestoreBackup: "从备份恢复 %s",
+	JournalLoadFailed:    "读取操作日志失败: %v",
+	JournalSaveFailed:    "保存操作日志失败: %v",
+	JournalFileModified:  "%s 在此次修改后已被外部修改，拒绝覆盖",
+	NothingToUndo:        "没有可撤销的操作",
+	NothingToRedo:        "没有可重做的操作",
+	UndoneChange:         "已撤销: %s",
+	RedoneChange:         "已重做: %s",
+	KeyUndo:              "撤销",
+	KeyRedo:              "重做",
+	JournalHistoryTitle:  "操作日志（* 表示可撤销）:",
+	JournalEmpty:         "操作日志为空",
+
+	// 重命名与复制主机
+	RenameHostAction:           "重命名",
+	DuplicateHostAction:        "复制",
+	EnterRenameAlias:           "输入 %s 的新别名（多个用空格分隔）",
+	EnterDuplicateAlias:        "输入 %s 副本的别名（多个用空格分隔）",
+	AliasRequired:              "别名不能为空",
+	AliasAlreadyExists:         "别名 '%s' 已存在",
+	HostBlockNotFound:          "配置文件中没有 '%s' 的 Host 配置块",
+	HostNotEditable:            "主机 '%s' 定义在 %s 中，sshgo 只能修改主配置文件",
+	FailedToRenameHost:         "重命名主机失败: %v",
+	FailedToDuplicateHost:      "复制主机失败: %v",
+	SuccessfullyRenamedHost:    "已将 '%s' 重命名为 '%s'",
+	SuccessfullyDuplicatedHost: "已将 '%s' 复制为 '%s'",
+	JournalRenameHost:          "将主机 %s 重命名为 %s",
+	JournalDuplicateHost:       "将主机 %s 复制为 %s",
+
+	// 标签与分组
+	EditTagsAction:          "编辑标签/分组",
+	EnterHostMeta:           "编辑 %s 的标签和分组（例如 tags=prod,db group=eu）",
+	InvalidHostMeta:         "无效的标签/分组设置 '%s'，应为 tags=a,b 或 group=name",
+	FailedToSetHostMeta:     "更新标签失败: %v",
+	SuccessfullySetHostMeta: "已更新 '%s' 的标签和分组",
+	JournalSetHostMeta:      "将 %s 的标签设置为 '%s'",
+	UngroupedHosts:          "未分组",
+	GroupHeader:             "%s %s (%d)",
+	TagsLabel:               "标签: %s",
+	GroupLabel:              "分组: %s",
+	KeyToggleGroup:          "展开/折叠分组",
+
+	// 收藏与排序
+	SortFrecency:        "常用",
+	SortAlphabetical:    "字母",
+	SortRecent:          "最近使用",
+	SortFileOrder:       "文件顺序",
+	HostListTitleSorted: "%s（排序: %s）",
+	SortModeChanged:     "按%s排序",
+	FavoriteAdded:       "已将 '%s' 置顶收藏",
+	FavoriteRemoved:     "已取消收藏 '%s'",
+	KeySort:             "排序",
+	KeyFavorite:         "收藏",
+	SaveUsageFailed:     "保存使用记录失败: %v",
+	LastConnected:       "上次连接: %s（共 %d 次）",
+
+	// 连接历史
+	HistoryTitle:        "连接历史",
+	HostHistoryTitle:    "连接历史 - %s",
+	HistoryAction:       "连接历史",
+	NoHistoryFound:      "暂无连接历史",
+	LoadHistoryFailed:   "读取连接历史失败: %v",
+	HistoryEntryDesc:    "%s · 时长 %s · 退出码 %d",
+	HistoryEntryError:   "%s · 失败: %s",
+	KeyHistory:          "历史",
+	KeyReconnect:        "重新连接",
+	HistoryUsage:        " sshgo history -r <index>|用法: sshgo history [-n 数量] [别名] | sshgo history -r <序号>",
+	InvalidHistoryIndex: "无效的历史序号: %s",
+
+	// 多选与批量操作
+	BatchActionTitle:          "对选中的 %d 个主机执行批量操作",
+	BatchSetUserAction:        "设置用户",
+	BatchSetPortAction:        "设置端口",
+	BatchSetKeyAction:         "设置密钥文件",
+	BatchDeleteAction:         "删除配置",
+	BatchCheckAction:          "检查可达性",
+	BatchExportAction:         "导出",
+	BatchClearAction:          "清除选择",
+	EnterBatchValue:           "为 %[2]d 个主机输入 %[1]s",
+	EnterExportPath:           "导出 %d 个主机到文件",
+	ConfirmBatchDelete:        "确定要删除 %d 个主机的配置吗: %s?",
+	SuccessfullyBatchSet:      "已将 %[3]d 个主机的 %[1]s 设置为 '%[2]s'",
+	SuccessfullyBatchDeleted:  "已删除 %d 个主机的配置",
+	SuccessfullyExportedHosts: "已导出 %d 个主机到 %s",
+	FailedToBatchEdit:         "批量修改失败: %v",
+	FailedToExportHosts:       "导出主机失败: %v",
+	ExportFileExists:          "导出主机失败: %s 已存在",
+	JournalBatchSet:           "将 %[3]d 个主机的 %[1]s 设置为 %[2]s",
+	JournalBatchDelete:        "删除 %d 个主机",
+	ReachabilityTitle:         "%d 个主机的可达性",
+	ReachabilityOK:            "✓ %-24s %s:%s  %v",
+	ReachabilityFailed:        "✗ %-24s %s:%s  %v",
+	ReachabilityPending:       "… %-24s %s:%s",
+	ReachabilitySummary:       "%d 个可达，%d 个不可达，%d 个检测中",
+	SelectedCount:             "已选择 %d 个",
+	KeyMark:                   "选择",
+	KeyMarkAll:                "全选",
+
+	// 端口校验
+	InvalidPortNumber: "无效的端口号: %s",
+
+	// 并发执行远程命令
+	BatchRunAction:             "执行命令",
+	EnterRunCommand:            "在 %d 个主机上执行的命令",
+	RunTitle:                   "在 %d 个主机上执行: %s",
+	RunStatusPending:           "等待中",
+	RunStatusRunning:           "运行中",
+	RunStatusOK:                "成功",
+	RunStatusExit:              "退出码 %d",
+	RunStatusError:             "错误",
+	RunStatusCancelled:         "已取消",
+	RunSummary:                 "%d 个成功，%d 个失败，%d 个运行中，%d 个等待中",
+	RunHelpRunning:             "%s: 选择主机 • %s: 保存输出 • %s: 取消执行",
+	RunHelpDone:                "%s: 选择主机 • %s: 保存输出 • %s: 返回",
+	RunNoOutput:                "（无输出）",
+	EnterRunOutputPath:         "将 %d 个主机的合并输出保存到文件",
+	SuccessfullySavedRunOutput: "已将 %d 个主机的输出保存到 %s",
+	FailedToSaveRunOutput:      "保存输出失败: %v",
+	RunOutputFileExists:        "保存输出失败: %s 已存在",
+	RemoteCommandRequired:      "命令不能为空",
+
+	// SFTP 文件浏览与传输
+	SFTPAction:            "文件浏览 (SFTP)",
+	SFTPConnecting:        "正在打开到 %s 的 SFTP 会话...",
+	SFTPConnectFailed:     "打开到 %s 的 SFTP 会话失败: %v",
+	TransferFailed:        "传输 %s 失败: %v",
+	SFTPTitle:             "SFTP - %s",
+	SFTPLocalPane:         "本地: %s",
+	SFTPRemotePane:        "%s: %s",
+	SFTPEmptyDir:          "（空目录）",
+	SFTPHelp:              "%s: 切换面板 • %s: 打开 • %s: 上级目录 • %s: 复制到另一侧 • %s: 断点续传 • %s: 退出",
+	SFTPHelpTransfer:      "%s: 取消传输",
+	SFTPUploaded:          "已上传 %s 到 %s",
+	SFTPDownloaded:        "已下载 %s 到 %s",
+	SFTPTransferCancelled: "传输已取消，按 %s 断点续传",
+	SFTPTransferRetryHint: "%v（按 %s 断点续传）",
+	SFTPNothingToResume:   "没有可续传的任务",
+
+	// sshgo cp 文件复制
+	ProxyJumpLabel:         "跳板机: %s",
+	ChecksumMismatch:       "校验和不一致（源 %s，目标 %s）",
+	CpUsage:                "用法: sshgo cp [-r] [-q] [-c|--checksum] [--resume] <源>... <目标>（远程路径: [user@]host:path）",
+	CpUnsupportedDirection: "只支持在本地路径与单个远程主机之间复制",
+	CpTargetNotDirectory:   "目标 %s 不是目录",
+	CpIsDirectory:          "是目录（使用 -r 递归复制）",
+	CpNoMatch:              "没有匹配的文件: %s",
+	CpSummary:              "已复制 %d 个文件（%s），用时 %v",
+
+	// tmux/screen 启动
+	ConnectNewWindowAction: "在新窗口中连接",
+	BatchTiledAction:       "平铺窗格连接（同步输入）",
+	InvalidLaunchMode:      "无效的启动方式: %s（可选 current、window、pane）",
+	NotInMultiplexer:       "当前不在 tmux 或 screen 中",
+	NoHostsSelected:        "没有选择主机",
+	TmuxRequired:           "平铺窗格需要安装 tmux",
+	OpenedInNewWindow:      "已在新窗口中打开 %s",
+	OpenedTiledPanes:       "已在平铺窗格中打开 %d 个主机（同步输入）",
+
+	// 会话录制与回放
+	ConnectRecordAction:      "连接并录制会话",
+	RecordingsAction:         "会话录制",
+	RecordingTo:              "会话录制到 %s",
+	RecordingUnsupported:     "当前平台不支持会话录制",
+	RecordingsTitle:          "会话录制（速度 %sx）",
+	HostRecordingsTitle:      "会话录制: %s（速度 %sx）",
+	NoRecordingsFound:        "没有找到会话录制",
+	LoadRecordingsFailed:     "读取录制失败: %v",
+	PlaybackFailed:           "回放失败: %v",
+	PlaybackTerminalTooSmall: "录制尺寸为 %dx%d，当前终端为 %dx%d，输出可能换行",
+	PlaybackFinished:         "回放结束，按任意键返回",
+	KeyRecordings:            "录制",
+	KeyPlay:                  "回放",
+	KeySpeed:                 "速度",
+
+	// 远程命令
+	SetRemoteCommandAction:           "设置远程命令",
+	ConnectRunAction:                 "连接并执行命令...",
+	EnterRemoteCommand:               "每次连接 %s 后在远程执行的命令（如 sudo -i、tmux new -A -s main）",
+	EnterConnectRunCommand:           "连接 %s 后执行的命令",
+	RemoteCommandHint:                "留空表示清除。保存为 RemoteCommand；未设置 RequestTTY 时会请求伪终端。",
+	SuccessfullySetRemoteCommand:     "已将 '%s' 的远程命令设置为 '%s'",
+	SuccessfullyClearedRemoteCommand: "已清除 '%s' 的远程命令",
+	FailedToSetRemoteCommand:         "设置远程命令失败: %v",
+	JournalSetRemoteCommand:          "将 %s 的远程命令设置为 '%s'",
+	JournalClearRemoteCommand:        "清除 %s 的远程命令",
+	InvalidRequestTTY:                "无效的 RequestTTY 值: %s（可选 yes、no、force、auto）",
+	RemoteCommandLabel:               "远程命令: %s",
+	RequestTTYLabel:                  "RequestTTY: %s",
+
+	// 命令行 ssh 选项
+	OptionRequiresArgument: "选项 %s 需要参数",
+	HostArgumentRequired:   "缺少主机参数（用法: sshgo [ssh 选项] [user@]host[:port] [命令...]）",
+
+	// 命令行主机匹配
+	AmbiguousHost: "'%s' 匹配到多个主机: %s",
+	HostMatched:   "'%s' 匹配到主机 %s",
+
+	// sshgo 配置文件
+	SettingsLoadFailed:   "读取配置文件 %s 失败: %v",
+	SettingUnknownKey:    "未知的配置项: %s",
+	SettingInvalidChoice: "%s 的值无效: %s（可选 %s）",
+	SettingTooSmall:      "%s 的值无效: %d（不能小于 %d）",
+	SettingNotPositive:   "%s 的值无效: %v（必须大于 0）",
+	SettingInvalidValue:  "%s 的值无效: %s（%v）",
+	SettingUpdated:       "已设置 %s = %s",
+	SettingReset:         "已将 %s 恢复为默认值",
+	ConfigUsage:          "用法: sshgo config [list | path | get <键> | set <键> <值> | unset <键>]",
+
+	// 按键绑定
+	SettingKeyConflict: "按键 %s 同时绑定到了 keys.%s 和 keys.%s",
+	KeyHelp:            "帮助",
+	KeyUp:              "上移",
+	KeyDown:            "下移",
+	KeyPageUp:          "上一页",
+	KeyPageDown:        "下一页",
+	KeyTop:             "到开头",
+	KeyBottom:          "到末尾",
+	KeySpeedUp:         "加速",
+	KeySpeedDown:       "减速",
+	KeySaveOutput:      "保存输出",
+	KeySwitchPane:      "切换面板",
+	KeyOpen:            "进入目录",
+	KeyParent:          "上级目录",
+	KeyCopy:            "复制到另一侧",
+	KeyResume:          "断点续传",
+	KeyForceQuit:       "在任何界面退出",
+	KeyHelpTitle:       "按键绑定（方案: %s）",
+	KeyHelpGeneral:     "通用",
+	KeyHelpNavigation:  "移动",
+	KeyHelpDialogs:     "确认",
+	KeyHelpHostList:    "主机列表",
+	KeyHelpRecordings:  "会话录制",
+	KeyHelpRun:         "批量执行",
+	KeyHelpFiles:       "文件浏览",
+	KeyHelpFooter:      "文本框中使用 %s/%s • 按任意键关闭",
+
+	// 鼠标
+	KeyHelpMouse:     "鼠标",
+	MouseClick:       "选中主机、展开分组或执行菜单项",
+	MouseDoubleClick: "连接主机",
+	MouseWheel:       "上下移动",
+
+	// 命令面板
+	KeyPalette:            "命令面板",
+	PaletteTitle:          "命令面板",
+	PalettePlaceholder:    "输入以搜索命令",
+	PaletteNoMatches:      "没有匹配的命令",
+	PaletteHelp:           "↑/↓: 选择 • %s: 执行 • %s: 关闭",
+	CommandReloadConfig:   "重新加载 SSH 配置",
+	ConfigReloadedAll:     "已重新加载 SSH 配置",
+	CommandSwitchLanguage: "切换语言 (中文 / English)",
+	LanguageSwitched:      "已切换为中文",
+	CommandOpenHistory:    "打开连接历史",
+	CommandOpenRecordings: "打开会话录制",
+	CommandCycleSort:      "切换排序方式",
+	CommandToggleFavorite: "收藏/取消收藏当前主机",
+	CommandMarkAll:        "选择/取消选择全部主机",
+	CommandClearMarks:     "清除选择",
+	CommandBatchMenu:      "批量操作已选择的主机",
+	CommandUndo:           "撤销上次配置修改",
+	CommandRedo:           "重做上次撤销的修改",
+	CommandShowKeys:       "显示按键帮助",
+	CommandQuit:           "退出 sshgo",
+
+	// 界面主题
+	SettingInvalidColor: "%s 的颜色无效: %q（请使用 #rrggbb、#rgb 或 0-255 的终端颜色编号）",
+
+	// 配置文件变化
+	ConfigModifiedExternally: "%s 在读取后已被其他程序修改",
+	ConfigReloaded:           "%s 已变化，已重新载入主机列表",
+	ConfigConflictTitle:      "配置文件已被外部修改",
+	ConfigConflictPrompt:     "%s 在 sshgo 读取后被其他程序修改。\n合并：在当前文件内容的基础上应用本次修改；放弃：取消本次修改并重新载入主机列表。",
+	ConfigConflictHelp:       "%s: 合并 • %s/%s: 放弃",
+	ConfigConflictAborted:    "已放弃修改，主机列表已重新载入",
+	SettingNegative:          "%s 的值无效: %v（不能为负数）",
+
+	// 主机预览
+	PreviewConfigTitle:    "实际生效的配置（ssh -G）",
+	PreviewLoading:        "加载中...",
+	PreviewChecking:       "可达性: 检查中...",
+	PreviewReachable:      "可达性: 可达（%s）",
+	PreviewUnreachable:    "可达性: 不可达（%v）",
+	PreviewNeverConnected: "上次连接: 从未连接",
+	PreviewKeyInfo:        "密钥: %s %s（%s）",
+	PreviewKeyNoPublic:    "密钥: %s（没有 .pub 文件）",
+	PreviewKeyMissing:     "密钥: %s（文件不存在）",
+	PreviewPattern:        "通配符配置块：其中的设置作用于所有匹配的主机",
+
+	// 主机搜索
+	ListUsage:      "用法: sshgo list [-q|--query <条件>] [条件...]（字段: host: user: port: tag: group: key: via:，前加 - 表示排除）",
+	NoHostsMatched: "没有匹配 %q 的主机",
 }
@@ -1,28 +1,44 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 
-	"sshgo/ssh"
+	"sshgo/cli"
+	"sshgo/i18n"
+	"sshgo/settings"
 	"sshgo/ui"
 )
 
 func main() {
+	// 读取 sshgo 配置文件（有误时提示并使用默认配置）
+	if err := settings.Init(); err != nil {
+		fmt.Printf(i18n.T(i18n.Warning)+"\n", err)
+	}
+
 	// 检查命令行参数
 	if len(os.Args) > 1 {
-		// 如果提供了参数，直接连接到指定主机
-		hostArg := os.Args[1]
-		host := ssh.ParseHostArgument(hostArg)
-		
-		// 如果没有用户名，使用默认用户名
-		if host.User == "" {
-			host.User = "root"
+		// 优先处理子命令
+		if handled, err := cli.Run(os.Args[1:]); handled {
+			if err != nil {
+				fmt.Printf("%v\n", err)
+				os.Exit(1)
+			}
+			return
 		}
-		
-		err := ssh.ConnectToHost(host)
+
+		// 按主机参数连接（支持别名匹配、额外的 ssh 选项和远程命令）
+		err := cli.Connect(os.Args[1:])
 		if err != nil {
+			// 远程命令的退出码原样返回
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
 			fmt.Printf("%v\n", err)
+			os.Exit(1)
 		}
 		return
 	}
@@ -0,0 +1,146 @@
+package mux
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"sshgo/i18n"
+	"sshgo/settings"
+)
+
+// Kind 终端复用器类型
+type Kind int
+
+const (
+	None Kind = iota
+	Tmux
+	Screen
+)
+
+// LaunchMode 在复用器中启动连接的方式
+type LaunchMode string
+
+const (
+	LaunchCurrent LaunchMode = "current" // 在当前窗格中连接（默认）
+	LaunchWindow  LaunchMode = "window"  // 新窗口
+	LaunchPane    LaunchMode = "pane"    // 新窗格（screen 不支持，退化为新窗口）
+)
+
+// Detect 检测当前是否运行在 tmux 或 screen 中
+func Detect() Kind {
+	if os.Getenv("TMUX") != "" {
+		return Tmux
+	}
+	if os.Getenv("STY") != "" {
+		return Screen
+	}
+	return None
+}
+
+// ParseLaunchMode 解析启动方式
+func ParseLaunchMode(s string) (LaunchMode, error) {
+	switch mode := LaunchMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case "":
+		return LaunchCurrent, nil
+	case LaunchCurrent, LaunchWindow, LaunchPane:
+		return mode, nil
+	}
+	return LaunchCurrent, fmt.Errorf("%s", i18n.TWithArgs(i18n.InvalidLaunchMode, s))
+}
+
+// DefaultLaunchMode 返回配置的启动方式（connect.launch，可由环境变量 SSHGO_LAUNCH 覆盖）
+func DefaultLaunchMode() LaunchMode {
+	mode, _ := ParseLaunchMode(settings.Current().Connect.Launch)
+	return mode
+}
+
+// Open 在复用器的新窗口或新窗格中运行命令，窗口以 name 命名
+func Open(kind Kind, mode LaunchMode, name string, argv []string) error {
+	switch kind {
+	case Tmux:
+		args := []string{"new-window", "-n", name}
+		if mode == LaunchPane {
+			args = []string{"split-window"}
+		}
+		if err := run("tmux", append(args, argv...)...); err != nil {
+			return err
+		}
+		if mode == LaunchPane {
+			// 设置窗格标题，便于区分多个连接
+			_ = run("tmux", "select-pane", "-T", name)
+		}
+		return nil
+	case Screen:
+		return run("screen", append([]string{"-X", "screen", "-t", name}, argv...)...)
+	}
+	return fmt.Errorf("%s", i18n.T(i18n.NotInMultiplexer))
+}
+
+// OpenTiled 在 tmux 新窗口中以平铺窗格运行多条命令，并开启同步输入
+// 已在 tmux 中时直接创建窗口并返回 nil；否则创建一个后台会话，
+// 返回用于接入该会话的命令，由调用方在终端中运行
+func OpenTiled(name string, commands [][]string) (*exec.Cmd, error) {
+	if len(commands) == 0 {
+		return nil, fmt.Errorf("%s", i18n.T(i18n.NoHostsSelected))
+	}
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return nil, fmt.Errorf("%s", i18n.T(i18n.TmuxRequired))
+	}
+
+	inside := Detect() == Tmux
+	var args []string
+	if inside {
+		args = []string{"new-window", "-P", "-F", "#{window_id}", "-n", name}
+	} else {
+		args = []string{"new-session", "-d", "-P", "-F", "#{session_id}:#{window_id}", "-n", name}
+	}
+	out, err := output("tmux", append(args, commands[0]...)...)
+	if err != nil {
+		return nil, err
+	}
+	target := strings.TrimSpace(out)
+
+	for _, argv := range commands[1:] {
+		if err := run("tmux", append([]string{"split-window", "-t", target}, argv...)...); err != nil {
+			return nil, err
+		}
+		// 每次分割后重新平铺，避免窗格过多时空间不足
+		if err := run("tmux", "select-layout", "-t", target, "tiled"); err != nil {
+			return nil, err
+		}
+	}
+	if err := run("tmux", "set-window-option", "-t", target, "synchronize-panes", "on"); err != nil {
+		return nil, err
+	}
+
+	if inside {
+		return nil, nil
+	}
+	session, _, _ := strings.Cut(target, ":")
+	cmd := exec.Command("tmux", "attach-session", "-t", session)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd, nil
+}
+
+// run 执行复用器命令，失败时带上其错误输出
+func run(name string, args ...string) error {
+	_, err := output(name, args...)
+	return err
+}
+
+// output 执行复用器命令并返回标准输出
+func output(name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s %s: %s", name, args[0], msg)
+		}
+		return "", fmt.Errorf("%s %s: %w", name, args[0], err)
+	}
+	return string(out), nil
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"time"
 
+	"sshgo/settings"
+
 	"golang.org/x/net/icmp"
 	"golang.org/x/net/ipv4"
 )
@@ -26,11 +28,13 @@ type RouteHop struct {
 }
 
 // 延迟测量器
-type LatencyMeasurer struct{}
+type LatencyMeasurer struct {
+	timeout time.Duration
+}
 
-// 创建新的延迟测量器
+// 创建新的延迟测量器（超时取自 network.timeout）
 func NewLatencyMeasurer() *LatencyMeasurer {
-	return &LatencyMeasurer{}
+	return &LatencyMeasurer{timeout: settings.Current().Network.Timeout.Duration}
 }
 
 // 测量延迟
@@ -47,7 +51,7 @@ func (lm *LatencyMeasurer) MeasureLatency(host string, protocol string) (*Latenc
 func (lm *LatencyMeasurer) measureTCP(host string, port int) (*LatencyResult, error) {
 	start := time.Now()
 	
-	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 5*time.Second)
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), lm.timeout)
 	if err != nil {
 		return &LatencyResult{
 			Host:     host,
@@ -71,11 +75,12 @@ type RouteTracer struct {
 	timeout time.Duration
 }
 
-// 创建新的路由追踪器
+// 创建新的路由追踪器（最大跳数与每跳超时取自 network.trace_max_hops / network.trace_timeout）
 func NewRouteTracer() *RouteTracer {
+	cfg := settings.Current().Network
 	return &RouteTracer{
-		maxHops: 30,
-		timeout: 2 * time.Second,
+		maxHops: cfg.TraceMaxHops,
+		timeout: cfg.TraceTimeout.Duration,
 	}
 }
 
@@ -0,0 +1,20 @@
+package network
+
+import (
+	"net"
+	"time"
+)
+
+// CheckReachable 通过建立 TCP 连接检查主机端口是否可达，返回建立连接所用时间
+func CheckReachable(host, port string, timeout time.Duration) (time.Duration, error) {
+	if port == "" {
+		port = "22"
+	}
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	return time.Since(start), nil
+}
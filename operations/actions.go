@@ -1,13 +1,24 @@
 package operations
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 
 	"sshgo/i18n"
 	"sshgo/ssh"
 )
 
+// wrapError 用失败提示包装操作错误；配置文件冲突原样返回，以便 UI 提供合并或放弃
+func wrapError(format i18n.StringKey, err error) error {
+	var conflict *ssh.ConflictError
+	if errors.As(err, &conflict) {
+		return err
+	}
+	return fmt.Errorf(i18n.T(format), err)
+}
+
 // DeleteKeyFile 删除密钥文件（无需确认，确认由 UI 层处理）
 func DeleteKeyFile(host ssh.SSHHost) error {
 	if host.KeyFile == "" {
@@ -24,32 +35,47 @@ func DeleteKeyFile(host ssh.SSHHost) error {
 }
 
 // DeleteHostConfig 删除主机配置（无需确认，确认由 UI 层处理）
-func DeleteHostConfig(host ssh.SSHHost) error {
-	// 从SSH配置文件中删除主机配置
-	err := ssh.RemoveHostFromConfig(host.Host)
-	if err != nil {
-		fmt.Printf(i18n.T(i18n.DeleteHostConfigWarning)+"\n", err)
+// 配置已删除但清理 known_hosts 失败时返回警告，由 UI 层显示
+func DeleteHostConfig(host ssh.SSHHost) (warning string, err error) {
+	if err := host.CheckEditable(); err != nil {
+		return "", wrapError(i18n.FailedToDeleteConfig, err)
 	}
+	err = recordChange(i18n.TWithArgs(i18n.JournalDeleteHost, host.Host), func() error {
+		// 从SSH配置文件中删除主机配置（仅来自 known_hosts 的主机没有配置块）
+		if host.Source != "" {
+			if err := ssh.RemoveHostFromConfig(host.Host); err != nil {
+				return err
+			}
+		}
 
-	// 从known_hosts文件中删除主机记录
-	err = ssh.RemoveHostFromKnownHosts(host.Host)
+		// 从known_hosts文件中删除主机记录
+		if err := ssh.RemoveHostFromKnownHosts(host.Host); err != nil {
+			warning = fmt.Sprintf(i18n.T(i18n.DeleteKnownHostsWarning), err)
+		}
+		return nil
+	})
 	if err != nil {
-		fmt.Printf(i18n.T(i18n.DeleteKnownHostsWarning)+"\n", err)
+		return "", wrapError(i18n.FailedToDeleteConfig, err)
 	}
-
-	return nil
+	return warning, nil
 }
 
 // ModifyUser 修改主机用户（用户名由 UI 层获取）
 func ModifyUser(host ssh.SSHHost, newUser string) error {
+	if err := host.CheckEditable(); err != nil {
+		return wrapError(i18n.FailedToModifyUser, err)
+	}
+
 	if newUser == "" {
-		newUser = i18n.T(i18n.DefaultUsername)
+		newUser = ssh.DefaultUsername()
 	}
 
 	// 保存新用户名到配置文件
-	err := ssh.SaveUserToConfig(host.Host, newUser)
+	err := recordChange(i18n.TWithArgs(i18n.JournalModifyUser, host.Host, newUser), func() error {
+		return ssh.SaveUserToConfig(host.Host, newUser)
+	})
 	if err != nil {
-		return fmt.Errorf(i18n.T(i18n.FailedToModifyUser), err)
+		return wrapError(i18n.FailedToModifyUser, err)
 	}
 
 	return nil
@@ -57,15 +83,162 @@ func ModifyUser(host ssh.SSHHost, newUser string) error {
 
 // ModifyPort 修改主机端口（端口由 UI 层获取）
 func ModifyPort(host ssh.SSHHost, newPort string) error {
+	if err := host.CheckEditable(); err != nil {
+		return wrapError(i18n.FailedToModifyPort, err)
+	}
+
 	if newPort == "" {
 		newPort = "22"
 	}
 
 	// 保存新端口号到配置文件
-	err := ssh.SavePortToConfig(host.Host, newPort)
+	err := recordChange(i18n.TWithArgs(i18n.JournalModifyPort, host.Host, newPort), func() error {
+		return ssh.SavePortToConfig(host.Host, newPort)
+	})
+	if err != nil {
+		return wrapError(i18n.FailedToModifyPort, err)
+	}
+
+	return nil
+}
+
+// RestoreBackup 从备份恢复配置文件（记录到操作日志，可撤销）
+func RestoreBackup(backup ssh.Backup) error {
+	return recordChange(i18n.TWithArgs(i18n.JournalRestoreBackup, backup.Source), func() error {
+		return ssh.RestoreBackup(backup)
+	})
+}
+
+// RenameHost 重命名主机（新别名由 UI 层获取）
+func RenameHost(host ssh.SSHHost, newAlias string) error {
+	if err := host.CheckEditable(); err != nil {
+		return wrapError(i18n.FailedToRenameHost, err)
+	}
+	err := recordChange(i18n.TWithArgs(i18n.JournalRenameHost, host.Host, newAlias), func() error {
+		return ssh.RenameHost(host.Host, newAlias)
+	})
+	if err != nil {
+		return wrapError(i18n.FailedToRenameHost, err)
+	}
+	return nil
+}
+
+// DuplicateHost 复制主机配置（新别名由 UI 层获取）
+func DuplicateHost(host ssh.SSHHost, newAlias string) error {
+	if err := host.CheckEditable(); err != nil {
+		return wrapError(i18n.FailedToDuplicateHost, err)
+	}
+	err := recordChange(i18n.TWithArgs(i18n.JournalDuplicateHost, host.Host, newAlias), func() error {
+		return ssh.DuplicateHost(host.Host, newAlias)
+	})
+	if err != nil {
+		return wrapError(i18n.FailedToDuplicateHost, err)
+	}
+	return nil
+}
+
+// SetHostMeta 修改主机的标签与分组（元数据由 UI 层获取）
+func SetHostMeta(host ssh.SSHHost, meta ssh.HostMeta) error {
+	if err := host.CheckEditable(); err != nil {
+		return wrapError(i18n.FailedToSetHostMeta, err)
+	}
+	err := recordChange(i18n.TWithArgs(i18n.JournalSetHostMeta, host.Host, meta.String()), func() error {
+		return ssh.SetHostMeta(host.Host, meta)
+	})
+	if err != nil {
+		return wrapError(i18n.FailedToSetHostMeta, err)
+	}
+	return nil
+}
+
+// SetRemoteCommand 设置主机连接后执行的远程命令；命令为空时同时清除 RequestTTY
+func SetRemoteCommand(host ssh.SSHHost, command string) error {
+	if err := host.CheckEditable(); err != nil {
+		return wrapError(i18n.FailedToSetRemoteCommand, err)
+	}
+	description := i18n.TWithArgs(i18n.JournalSetRemoteCommand, host.Host, command)
+	requestTTY := host.RequestTTY
+	if command == "" {
+		description = i18n.TWithArgs(i18n.JournalClearRemoteCommand, host.Host)
+		requestTTY = ""
+	}
+	err := recordChange(description, func() error {
+		return ssh.SetRemoteCommand(host.Host, command, requestTTY)
+	})
 	if err != nil {
-		return fmt.Errorf(i18n.T(i18n.FailedToModifyPort), err)
+		return wrapError(i18n.FailedToSetRemoteCommand, err)
+	}
+	return nil
+}
+
+// BatchSetDirective 批量修改多个主机的同一指令（User / Port / IdentityFile），作为一次操作记录
+func BatchSetDirective(hosts []ssh.SSHHost, directive, value string) error {
+	if err := checkEditable(hosts); err != nil {
+		return wrapError(i18n.FailedToBatchEdit, err)
+	}
+	aliases := ssh.HostAliases(hosts)
+	err := recordChange(i18n.TWithArgs(i18n.JournalBatchSet, directive, value, len(hosts)), func() error {
+		return ssh.UpdateHostsDirective(aliases, directive, value)
+	})
+	if err != nil {
+		return wrapError(i18n.FailedToBatchEdit, err)
+	}
+	return nil
+}
+
+// BatchDeleteHosts 批量删除多个主机的配置（确认由 UI 层处理），作为一次操作记录
+func BatchDeleteHosts(hosts []ssh.SSHHost) error {
+	if err := checkEditable(hosts); err != nil {
+		return wrapError(i18n.FailedToBatchEdit, err)
+	}
+	aliases := ssh.HostAliases(hosts)
+	err := recordChange(i18n.TWithArgs(i18n.JournalBatchDelete, len(hosts)), func() error {
+		return ssh.RemoveHosts(aliases)
+	})
+	if err != nil {
+		return wrapError(i18n.FailedToBatchEdit, err)
+	}
+	return nil
+}
+
+// checkEditable 检查批量操作的主机都定义在主配置文件中
+func checkEditable(hosts []ssh.SSHHost) error {
+	for _, h := range hosts {
+		if err := h.CheckEditable(); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// ExportHosts 将主机配置导出到新文件，不覆盖已存在的文件
+func ExportHosts(hosts []ssh.SSHHost, path string) error {
+	content, err := ssh.ExportHosts(hosts)
+	if err != nil {
+		return fmt.Errorf(i18n.T(i18n.FailedToExportHosts), err)
+	}
+	err = writeNewFile(path, content)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.ExportFileExists, path))
+	}
+	if err != nil {
+		return fmt.Errorf(i18n.T(i18n.FailedToExportHosts), err)
+	}
 	return nil
 }
+
+// writeNewFile 创建新文件并写入内容，不覆盖已存在的文件（返回 fs.ErrExist）；写入失败时删除不完整的文件
+func writeNewFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
@@ -0,0 +1,201 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/mux"
+	"sshgo/recording"
+	"sshgo/settings"
+	"sshgo/ssh"
+	"sshgo/state"
+)
+
+// ConnectToHost 连接到主机，并记录使用情况（用于最近使用/常用排序）和连接历史
+// 在 tmux/screen 中且 connect.launch 为 window 或 pane 时，在新窗口/窗格中打开连接；
+// connect.record 开启时录制会话；命令行指定的远程命令总是在当前终端中执行，以便取得输出和退出码
+func ConnectToHost(host ssh.SSHHost) error {
+	host = ssh.ResolveUser(host)
+	if len(host.Command) == 0 && LaunchesInMultiplexer() {
+		return connectInMultiplexer(mux.Detect(), mux.DefaultLaunchMode(), host)
+	}
+
+	return connect(host, settings.Current().Connect.Record)
+}
+
+// LaunchesInMultiplexer 连接是否会在 tmux/screen 的新窗口/窗格中打开（而不占用当前终端）
+func LaunchesInMultiplexer() bool {
+	return mux.DefaultLaunchMode() != mux.LaunchCurrent && mux.Detect() != mux.None
+}
+
+// ConnectAndRecord 连接到主机并将会话录制为 asciicast v2 文件
+func ConnectAndRecord(host ssh.SSHHost) error {
+	return connect(ssh.ResolveUser(host), true)
+}
+
+// connect 在当前终端中连接主机，可选录制会话
+func connect(host ssh.SSHHost, record bool) error {
+	if !record {
+		conn, err := PrepareConnection(host)
+		if err != nil {
+			return err
+		}
+		// 执行远程命令时不输出提示，便于通过管道使用命令输出
+		if len(conn.Host.Command) == 0 {
+			fmt.Println(i18n.TWithArgs(i18n.ConnectingTo, conn.Host.User, conn.Host.Host))
+		}
+		return conn.Finish(conn.Cmd.Run())
+	}
+
+	// 使用记录失败不影响连接
+	_ = state.RecordConnection(host.Host)
+	entry := newHistoryEntry(host)
+	var err error
+	entry.Recording, err = recordSession(host, entry.Start)
+	finishHistory(entry, err)
+	return err
+}
+
+// Connection 一次在当前终端中进行的连接：调用方运行 Cmd（如交给 TUI 的 tea.ExecProcess），
+// 结束后调用 Finish 写入连接历史
+type Connection struct {
+	Host  ssh.SSHHost
+	Cmd   *exec.Cmd
+	entry state.HistoryEntry
+}
+
+// PrepareConnection 构建连接主机的 ssh 命令并记录使用情况，命令的输入输出为当前终端
+func PrepareConnection(host ssh.SSHHost) (*Connection, error) {
+	// 未配置用户时与 ssh 一致，使用本地用户名
+	host = ssh.ResolveUser(host)
+	if host.User == "" {
+		return nil, fmt.Errorf("%s", i18n.T(i18n.UsernameNotSet))
+	}
+	argv, err := ssh.CommandArgs(host)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// 使用记录失败不影响连接
+	_ = state.RecordConnection(host.Host)
+	return &Connection{Host: host, Cmd: cmd, entry: newHistoryEntry(host)}, nil
+}
+
+// Finish 记录连接结束时间和退出状态，返回 ssh 的错误
+func (c *Connection) Finish(err error) error {
+	finishHistory(c.entry, err)
+	return err
+}
+
+// newHistoryEntry 创建从当前时间开始的连接历史记录；命令行指定了远程命令时记录该命令
+func newHistoryEntry(host ssh.SSHHost) state.HistoryEntry {
+	command := host.RemoteCommand
+	if len(host.Command) > 0 {
+		command = strings.Join(host.Command, " ")
+	}
+	return state.HistoryEntry{
+		Alias:    host.Host,
+		HostName: host.Target(),
+		User:     host.User,
+		Port:     host.Port,
+		KeyFile:  host.KeyFile,
+		Start:    time.Now(),
+		Command:  command,
+	}
+}
+
+// finishHistory 填写结束时间和退出状态并写入连接历史
+func finishHistory(entry state.HistoryEntry, err error) {
+	entry.End = time.Now()
+	entry.ExitCode = exitCode(err)
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	_ = state.AppendHistory(entry)
+}
+
+// recordSession 在伪终端中连接主机并录制会话，返回录制文件路径
+func recordSession(host ssh.SSHHost, start time.Time) (string, error) {
+	argv, err := ssh.CommandArgs(host)
+	if err != nil {
+		return "", err
+	}
+	path, err := recording.NewPath(host.Host, start)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Println(i18n.TWithArgs(i18n.ConnectingTo, host.User, host.Host))
+	fmt.Println(i18n.TWithArgs(i18n.RecordingTo, path))
+	title := fmt.Sprintf("%s@%s", host.User, host.Host)
+	return path, recording.Record(exec.Command(argv[0], argv[1:]...), path, title)
+}
+
+// connectInMultiplexer 在复用器的新窗口/窗格中连接，窗口以别名命名
+// 连接在后台窗口中运行，无法得知结束时间和退出码，因此只记录使用情况而不写入连接历史
+func connectInMultiplexer(kind mux.Kind, mode mux.LaunchMode, host ssh.SSHHost) error {
+	argv, err := ssh.CommandArgs(host)
+	if err != nil {
+		return err
+	}
+	if err := mux.Open(kind, mode, host.Host, argv); err != nil {
+		return err
+	}
+	_ = state.RecordConnection(host.Host)
+	return nil
+}
+
+// ConnectInNewWindow 在当前 tmux/screen 会话的新窗口中连接主机
+func ConnectInNewWindow(host ssh.SSHHost) error {
+	return connectInMultiplexer(mux.Detect(), mux.LaunchWindow, ssh.ResolveUser(host))
+}
+
+// ConnectTiled 在 tmux 平铺窗格中同时连接多个主机，并开启同步输入
+// 不在 tmux 中时返回接入新会话的命令，由调用方在终端中运行
+func ConnectTiled(hosts []ssh.SSHHost) (*exec.Cmd, error) {
+	var commands [][]string
+	for _, h := range hosts {
+		argv, err := ssh.CommandArgs(ssh.ResolveUser(h))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", h.Host, err)
+		}
+		commands = append(commands, argv)
+	}
+
+	name := "sshgo"
+	if len(hosts) == 1 {
+		name = hosts[0].Host
+	} else if len(hosts) > 1 {
+		name = fmt.Sprintf("%s+%d", hosts[0].Host, len(hosts)-1)
+	}
+	cmd, err := mux.OpenTiled(name, commands)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range hosts {
+		_ = state.RecordConnection(h.Host)
+	}
+	return cmd, nil
+}
+
+// exitCode 从连接错误中提取退出码：成功为 0，未能启动 ssh 为 -1
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
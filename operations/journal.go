@@ -0,0 +1,257 @@
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/ssh"
+	"sshgo/xdg"
+)
+
+// 操作日志保留的最大条目数
+const maxJournalEntries = 50
+
+// 操作日志文件名（位于状态目录）
+const journalFileName = "journal.json"
+
+// FileChange 记录单个文件在一次操作前后的内容
+type FileChange struct {
+	Path          string `json:"path"`
+	Before        string `json:"before"`
+	After         string `json:"after"`
+	ExistedBefore bool   `json:"existed_before"`
+}
+
+// JournalEntry 一次配置修改操作
+type JournalEntry struct {
+	Time        time.Time    `json:"time"`
+	Description string       `json:"description"`
+	Changes     []FileChange `json:"changes"`
+}
+
+// journal 操作日志：Entries[:Cursor] 为已应用（可撤销），Entries[Cursor:] 为已撤销（可重做）
+type journal struct {
+	Entries []JournalEntry `json:"entries"`
+	Cursor  int            `json:"cursor"`
+}
+
+// journalPaths 操作可能修改的文件：sshgo 只修改主配置文件与 known_hosts（见 ssh.SSHHost.CheckEditable）
+func journalPaths() []string {
+	return []string{ssh.GetSSHConfigPath(), ssh.GetKnownHostsPath()}
+}
+
+// loadJournal 读取操作日志，文件不存在时返回空日志
+func loadJournal() (*journal, error) {
+	path, err := xdg.StatePath(journalFileName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &journal{}, nil
+		}
+		return nil, err
+	}
+
+	var j journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, err
+	}
+	if j.Cursor < 0 || j.Cursor > len(j.Entries) {
+		j.Cursor = len(j.Entries)
+	}
+	return &j, nil
+}
+
+// save 保存操作日志
+func (j *journal) save() error {
+	path, err := xdg.StatePath(journalFileName)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ssh.WriteFileAtomic(path, data, 0600)
+}
+
+// snapshot 读取文件当前内容
+func snapshot(paths []string) (map[string]FileChange, error) {
+	result := make(map[string]FileChange, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				result[path] = FileChange{Path: path}
+				continue
+			}
+			return nil, err
+		}
+		result[path] = FileChange{Path: path, Before: string(data), ExistedBefore: true}
+	}
+	return result, nil
+}
+
+// recordChange 执行 fn 并将其对配置文件造成的修改记录到操作日志
+// 文件在 sshgo 读取后被外部修改时不执行并返回 *ssh.ConflictError；日志读写失败不会影响操作本身
+func recordChange(description string, fn func() error) error {
+	paths := journalPaths()
+	if err := ssh.CheckUnchanged(paths...); err != nil {
+		return err
+	}
+	before, snapErr := snapshot(paths)
+
+	if err := fn(); err != nil {
+		return err
+	}
+	if snapErr != nil {
+		return nil
+	}
+
+	var changes []FileChange
+	for _, path := range paths {
+		change := before[path]
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			continue
+		}
+		change.After = string(data)
+		if change.After != change.Before {
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	j, err := loadJournal()
+	if err != nil {
+		return nil
+	}
+	// 新操作会丢弃所有可重做的条目
+	j.Entries = append(j.Entries[:j.Cursor], JournalEntry{
+		Time:        time.Now(),
+		Description: description,
+		Changes:     changes,
+	})
+	if len(j.Entries) > maxJournalEntries {
+		j.Entries = j.Entries[len(j.Entries)-maxJournalEntries:]
+	}
+	j.Cursor = len(j.Entries)
+	_ = j.save()
+	return nil
+}
+
+// applyContents 将条目中的文件恢复为修改前（undo）或修改后（redo）的内容
+// 文件在 sshgo 读取后被外部修改时返回 *ssh.ConflictError；与条目记录的内容不一致时同样拒绝执行
+func applyContents(changes []FileChange, undo bool) error {
+	paths := make([]string, len(changes))
+	for i, c := range changes {
+		paths[i] = c.Path
+	}
+	if err := ssh.CheckUnchanged(paths...); err != nil {
+		return err
+	}
+
+	// 先全部校验，避免只恢复一半
+	for _, c := range changes {
+		expected := c.Before
+		if undo {
+			expected = c.After
+		}
+		current, err := os.ReadFile(c.Path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if string(current) != expected {
+			return fmt.Errorf("%s", i18n.TWithArgs(i18n.JournalFileModified, c.Path))
+		}
+	}
+
+	for _, c := range changes {
+		content := c.After
+		if undo {
+			// 修改前文件不存在，撤销时直接删除
+			if !c.ExistedBefore {
+				if err := os.Remove(c.Path); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+				continue
+			}
+			content = c.Before
+		}
+		if err := ssh.WriteFileWithBackup(c.Path, []byte(content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Undo 撤销最近一次配置修改，返回被撤销的条目
+func Undo() (*JournalEntry, error) {
+	j, err := loadJournal()
+	if err != nil {
+		return nil, fmt.Errorf("%s", i18n.TWithArgs(i18n.JournalLoadFailed, err))
+	}
+	if j.Cursor == 0 {
+		return nil, fmt.Errorf("%s", i18n.T(i18n.NothingToUndo))
+	}
+
+	entry := j.Entries[j.Cursor-1]
+	if err := applyContents(entry.Changes, true); err != nil {
+		return nil, err
+	}
+	j.Cursor--
+	if err := j.save(); err != nil {
+		return &entry, fmt.Errorf("%s", i18n.TWithArgs(i18n.JournalSaveFailed, err))
+	}
+	return &entry, nil
+}
+
+// Redo 重做最近一次被撤销的配置修改，返回被重做的条目
+func Redo() (*JournalEntry, error) {
+	j, err := loadJournal()
+	if err != nil {
+		return nil, fmt.Errorf("%s", i18n.TWithArgs(i18n.JournalLoadFailed, err))
+	}
+	if j.Cursor >= len(j.Entries) {
+		return nil, fmt.Errorf("%s", i18n.T(i18n.NothingToRedo))
+	}
+
+	entry := j.Entries[j.Cursor]
+	if err := applyContents(entry.Changes, false); err != nil {
+		return nil, err
+	}
+	j.Cursor++
+	if err := j.save(); err != nil {
+		return &entry, fmt.Errorf("%s", i18n.TWithArgs(i18n.JournalSaveFailed, err))
+	}
+	return &entry, nil
+}
+
+// JournalHistory 返回操作日志条目及当前位置（Entries[:cursor] 为可撤销部分）
+func JournalHistory() ([]JournalEntry, int, error) {
+	j, err := loadJournal()
+	if err != nil {
+		return nil, 0, err
+	}
+	return j.Entries, j.Cursor, nil
+}
+
+// Diff 返回条目的统一 diff（undo 为 true 时方向为 After -> Before）
+func (e JournalEntry) Diff(undo bool) string {
+	var s string
+	for _, c := range e.Changes {
+		if undo {
+			s += ssh.UnifiedDiff(c.Path, c.Path, c.After, c.Before)
+		} else {
+			s += ssh.UnifiedDiff(c.Path, c.Path, c.Before, c.After)
+		}
+	}
+	return s
+}
@@ -0,0 +1,142 @@
+package operations
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sshgo/ssh"
+)
+
+const journalTestConfig = "Host web-1\n    User root\n"
+
+// setupJournalTest 使用临时主目录与状态目录，写入只有 web-1 的配置文件并读取一次
+func setupJournalTest(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Setenv("LOCALAPPDATA", filepath.Join(home, "state"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	t.Setenv("APPDATA", filepath.Join(home, "config"))
+
+	dir := filepath.Join(home, ".ssh")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"config": journalTestConfig, "known_hosts": ""} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	configPath := ssh.GetSSHConfigPath()
+	if _, err := ssh.ParseSSHConfig(configPath); err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
+
+func TestJournal(t *testing.T) {
+	type step struct {
+		op       string // user / port / undo / redo / external
+		value    string
+		wantErr  bool
+		conflict bool // 错误应为 *ssh.ConflictError
+	}
+	withUser := "Host web-1\n    User deploy\n"
+	withPort := "Host web-1\n    User deploy\n    Port 2222\n"
+	external := "Host web-1\n    User other\n"
+
+	tests := []struct {
+		name       string
+		steps      []step
+		wantConfig string
+		wantCursor int
+		wantLen    int
+	}{
+		{
+			name:       "record",
+			steps:      []step{{op: "user", value: "deploy"}},
+			wantConfig: withUser, wantCursor: 1, wantLen: 1,
+		},
+		{
+			name:       "undo restores the old content",
+			steps:      []step{{op: "user", value: "deploy"}, {op: "undo"}},
+			wantConfig: journalTestConfig, wantCursor: 0, wantLen: 1,
+		},
+		{
+			name:       "redo reapplies the change",
+			steps:      []step{{op: "user", value: "deploy"}, {op: "port", value: "2222"}, {op: "undo"}, {op: "undo"}, {op: "redo"}, {op: "redo"}},
+			wantConfig: withPort, wantCursor: 2, wantLen: 2,
+		},
+		{
+			name: "new change after undo drops the redo tail",
+			steps: []step{
+				{op: "user", value: "deploy"}, {op: "port", value: "2222"}, {op: "undo"},
+				{op: "user", value: "admin"}, {op: "redo", wantErr: true},
+			},
+			wantConfig: "Host web-1\n    User admin\n", wantCursor: 2, wantLen: 2,
+		},
+		{
+			name:       "undo refuses external changes",
+			steps:      []step{{op: "user", value: "deploy"}, {op: "external", value: external}, {op: "undo", wantErr: true, conflict: true}},
+			wantConfig: external, wantCursor: 1, wantLen: 1,
+		},
+		{
+			name:       "nothing to undo",
+			steps:      []step{{op: "undo", wantErr: true}},
+			wantConfig: journalTestConfig, wantCursor: 0, wantLen: 0,
+		},
+		{
+			name:       "nothing to redo",
+			steps:      []step{{op: "user", value: "deploy"}, {op: "redo", wantErr: true}},
+			wantConfig: withUser, wantCursor: 1, wantLen: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := setupJournalTest(t)
+			host := ssh.SSHHost{Host: "web-1", Source: configPath}
+			for _, s := range tt.steps {
+				var err error
+				switch s.op {
+				case "user":
+					err = ModifyUser(host, s.value)
+				case "port":
+					err = ModifyPort(host, s.value)
+				case "undo":
+					_, err = Undo()
+				case "redo":
+					_, err = Redo()
+				case "external":
+					err = os.WriteFile(configPath, []byte(s.value), 0600)
+				}
+				if (err != nil) != s.wantErr {
+					t.Fatalf("%s %s: error = %v, wantErr %v", s.op, s.value, err, s.wantErr)
+				}
+				var conflict *ssh.ConflictError
+				if errors.As(err, &conflict) != s.conflict {
+					t.Errorf("%s: error = %v, want conflict %v", s.op, err, s.conflict)
+				}
+			}
+
+			data, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.wantConfig {
+				t.Errorf("config =\n%s\nwant:\n%s", data, tt.wantConfig)
+			}
+			entries, cursor, err := JournalHistory()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cursor != tt.wantCursor || len(entries) != tt.wantLen {
+				t.Errorf("journal cursor = %d, entries = %d; want %d, %d", cursor, len(entries), tt.wantCursor, tt.wantLen)
+			}
+		})
+	}
+}
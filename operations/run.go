@@ -0,0 +1,114 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+
+	"sshgo/i18n"
+	"sshgo/settings"
+	"sshgo/ssh"
+)
+
+// RunEvent 并发执行远程命令时产生的事件：开始、输出片段或结束
+type RunEvent struct {
+	Alias    string
+	Started  bool
+	Output   string
+	Done     bool
+	ExitCode int
+	Err      error
+}
+
+// RunResult 单个主机的执行结果
+type RunResult struct {
+	Host     ssh.SSHHost
+	Output   string
+	Done     bool
+	ExitCode int
+	Err      error
+}
+
+// OK 命令是否执行成功（退出码为 0）
+func (r RunResult) OK() bool {
+	return r.Done && r.Err == nil && r.ExitCode == 0
+}
+
+// runOutputWriter 将 ssh 输出转换为 RunEvent 发送出去
+type runOutputWriter struct {
+	alias  string
+	events chan<- RunEvent
+}
+
+func (w *runOutputWriter) Write(p []byte) (int, error) {
+	w.events <- RunEvent{Alias: w.alias, Output: string(p)}
+	return len(p), nil
+}
+
+// RunOnHosts 以有限并发在多个主机上执行同一条命令，输出以事件流形式返回
+// workers 小于 1 时使用 batch.run_workers 的默认值；所有主机执行结束（或 ctx 被取消）后通道会被关闭，调用方需要持续读取直到关闭
+func RunOnHosts(ctx context.Context, hosts []ssh.SSHHost, command string, workers int) <-chan RunEvent {
+	if workers < 1 {
+		workers = settings.Defaults().Batch.RunWorkers
+	}
+	events := make(chan RunEvent, 64)
+
+	go func() {
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, workers)
+		for _, h := range hosts {
+			wg.Add(1)
+			go func(h ssh.SSHHost) {
+				defer wg.Done()
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					events <- RunEvent{Alias: h.Host, Done: true, ExitCode: -1, Err: ctx.Err()}
+					return
+				}
+				defer func() { <-sem }()
+
+				events <- RunEvent{Alias: h.Host, Started: true}
+				code, err := ssh.RunRemoteCommand(ctx, h, command, &runOutputWriter{alias: h.Host, events: events})
+				events <- RunEvent{Alias: h.Host, Done: true, ExitCode: code, Err: err}
+			}(h)
+		}
+		wg.Wait()
+		close(events)
+	}()
+
+	return events
+}
+
+// FormatRunOutput 将所有主机的输出合并为一份文本，每个主机一节
+func FormatRunOutput(command string, results []RunResult) string {
+	var s strings.Builder
+	s.WriteString("$ " + command + "\n")
+	for _, r := range results {
+		status := fmt.Sprintf("exit %d", r.ExitCode)
+		if r.Err != nil {
+			status = r.Err.Error()
+		}
+		fmt.Fprintf(&s, "\n===== %s (%s) — %s =====\n", r.Host.Host, r.Host.Target(), status)
+		s.WriteString(r.Output)
+		if r.Output != "" && !strings.HasSuffix(r.Output, "\n") {
+			s.WriteString("\n")
+		}
+	}
+	return s.String()
+}
+
+// SaveRunOutput 将合并后的输出保存到新文件，不覆盖已存在的文件
+func SaveRunOutput(path, command string, results []RunResult) error {
+	err := writeNewFile(path, FormatRunOutput(command, results))
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.RunOutputFileExists, path))
+	}
+	if err != nil {
+		return fmt.Errorf(i18n.T(i18n.FailedToSaveRunOutput), err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package operations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sshgo/ssh"
+)
+
+func TestSaveRunOutput(t *testing.T) {
+	results := []RunResult{{Host: ssh.SSHHost{Host: "web-1", HostName: "10.0.0.1"}, Output: "up 3 days", Done: true}}
+	want := FormatRunOutput("uptime", results)
+
+	path := filepath.Join(t.TempDir(), "out.txt")
+	if err := SaveRunOutput(path, "uptime", results); err != nil {
+		t.Fatalf("SaveRunOutput() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != want {
+		t.Errorf("saved output =\n%s\nwant:\n%s", data, want)
+	}
+
+	// 已存在的文件不覆盖
+	if err := os.WriteFile(path, []byte("keep"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveRunOutput(path, "uptime", results); err == nil {
+		t.Error("SaveRunOutput() to an existing file should fail")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "keep" {
+		t.Errorf("existing file overwritten: %q", data)
+	}
+}
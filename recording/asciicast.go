@@ -0,0 +1,161 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Header asciicast v2 文件头
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// 事件类型
+const (
+	EventOutput = "o"
+	EventResize = "r"
+)
+
+// Event asciicast v2 事件，序列化为 [time, type, data]
+type Event struct {
+	Time float64
+	Type string
+	Data string
+}
+
+// MarshalJSON 序列化为 JSON 数组
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{e.Time, e.Type, e.Data})
+}
+
+// UnmarshalJSON 从 JSON 数组解析
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 3 {
+		return fmt.Errorf("invalid asciicast event: %s", data)
+	}
+	if err := json.Unmarshal(raw[0], &e.Time); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw[1], &e.Type); err != nil {
+		return err
+	}
+	return json.Unmarshal(raw[2], &e.Data)
+}
+
+// Writer 以 asciicast v2 格式写入录制事件，可并发调用
+type Writer struct {
+	mu      sync.Mutex
+	w       io.Writer
+	start   time.Time
+	pending []byte // 被截断的 UTF-8 多字节字符，等待下次输出补全
+}
+
+// NewWriter 写入文件头并返回事件写入器
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	h.Version = 2
+	data, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, start: time.Now()}, nil
+}
+
+// Output 记录一段终端输出
+func (w *Writer) Output(data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data = append(w.pending, data...)
+	cut := incompleteSuffix(data)
+	w.pending = append([]byte(nil), data[len(data)-cut:]...)
+	data = data[:len(data)-cut]
+	if len(data) == 0 {
+		return nil
+	}
+	return w.writeEvent(EventOutput, string(data))
+}
+
+// Resize 记录终端尺寸变化
+func (w *Writer) Resize(cols, rows int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writeEvent(EventResize, fmt.Sprintf("%dx%d", cols, rows))
+}
+
+// writeEvent 写入一个事件行
+func (w *Writer) writeEvent(typ, data string) error {
+	e := Event{Time: time.Since(w.start).Seconds(), Type: typ, Data: data}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(append(line, '\n'))
+	return err
+}
+
+// incompleteSuffix 返回末尾不完整的 UTF-8 字符的字节数
+func incompleteSuffix(data []byte) int {
+	for i := 1; i <= utf8.UTFMax && i <= len(data); i++ {
+		b := data[len(data)-i]
+		if b < 0x80 {
+			return 0
+		}
+		if utf8.RuneStart(b) {
+			if utf8.FullRune(data[len(data)-i:]) {
+				return 0
+			}
+			return i
+		}
+	}
+	return 0
+}
+
+// Read 读取 asciicast v2 文件
+func Read(r io.Reader) (Header, []Event, error) {
+	var h Header
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return h, nil, err
+		}
+		return h, nil, io.ErrUnexpectedEOF
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil {
+		return h, nil, err
+	}
+	if h.Version != 2 {
+		return h, nil, fmt.Errorf("unsupported asciicast version: %d", h.Version)
+	}
+
+	var events []Event
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return h, events, err
+		}
+		events = append(events, e)
+	}
+	return h, events, scanner.Err()
+}
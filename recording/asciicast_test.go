@@ -0,0 +1,43 @@
+package recording
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriterRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Header{Width: 80, Height: 24, Title: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// “中”被拆成两段输出，应合并为一个完整事件
+	zh := []byte("中")
+	if err := w.Output(append([]byte("hi "), zh[:1]...)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Output(zh[1:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Resize(100, 30); err != nil {
+		t.Fatal(err)
+	}
+
+	h, events, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Version != 2 || h.Width != 80 || h.Height != 24 || h.Title != "web" {
+		t.Fatalf("unexpected header: %+v", h)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d: %+v", len(events), events)
+	}
+	if events[0].Data != "hi " || events[1].Data != "中" {
+		t.Errorf("unexpected output events: %q %q", events[0].Data, events[1].Data)
+	}
+	if events[2].Type != EventResize || events[2].Data != "100x30" {
+		t.Errorf("unexpected resize event: %+v", events[2])
+	}
+}
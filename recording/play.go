@@ -0,0 +1,171 @@
+package recording
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"sshgo/i18n"
+
+	"github.com/muesli/cancelreader"
+	"golang.org/x/term"
+)
+
+// Speeds 可选的播放速度
+var Speeds = []float64{0.25, 0.5, 1, 2, 4, 8}
+
+// maxIdle 回放时两次输出之间的最长等待时间，跳过录制中的长时间空闲
+const maxIdle = 2 * time.Second
+
+// Play 在当前终端中回放录制文件
+// 空格暂停/继续，+/- 调整速度，q 或 ctrl+c 退出
+func Play(path string, speed float64) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	h, events, err := Read(file)
+	file.Close()
+	if err != nil {
+		return err
+	}
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		oldState, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer term.Restore(fd, oldState)
+	}
+
+	input, err := newInputReader()
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+	defer input.Cancel()
+	keys := readKeys(input)
+
+	out := os.Stdout
+	fmt.Fprint(out, "\033[2J\033[H")
+	if w, hgt, err := term.GetSize(int(out.Fd())); err == nil && (w < h.Width || hgt < h.Height) {
+		fmt.Fprintf(out, "%s\r\n", i18n.TWithArgs(i18n.PlaybackTerminalTooSmall, h.Width, h.Height, w, hgt))
+	}
+
+	speedIndex := speedIndexOf(speed)
+	paused := false
+	prev := 0.0
+	for i := 0; i < len(events); {
+		e := events[i]
+		gap := min(time.Duration((e.Time-prev)*float64(time.Second)), maxIdle)
+
+		if paused {
+			k, ok := <-keys
+			if !ok || isQuitKey(k) {
+				return finishPlayback(out, keys, false)
+			}
+			paused, speedIndex = handlePlaybackKey(k, paused, speedIndex)
+			continue
+		}
+
+		start := time.Now()
+		timer := time.NewTimer(time.Duration(float64(gap) / Speeds[speedIndex]))
+		select {
+		case k, ok := <-keys:
+			timer.Stop()
+			if !ok || isQuitKey(k) {
+				return finishPlayback(out, keys, false)
+			}
+			// 已等待的时间按当前速度折算，从剩余时间继续
+			prev += time.Since(start).Seconds() * Speeds[speedIndex]
+			paused, speedIndex = handlePlaybackKey(k, paused, speedIndex)
+			continue
+		case <-timer.C:
+		}
+
+		if e.Type == EventOutput {
+			if _, err := io.WriteString(out, e.Data); err != nil {
+				return err
+			}
+		}
+		prev = e.Time
+		i++
+	}
+	return finishPlayback(out, keys, true)
+}
+
+// readKeys 在后台读取按键
+func readKeys(r io.Reader) <-chan byte {
+	keys := make(chan byte, 16)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 1)
+		for {
+			n, err := r.Read(buf)
+			if err != nil {
+				return
+			}
+			if n > 0 {
+				keys <- buf[0]
+			}
+		}
+	}()
+	return keys
+}
+
+// isQuitKey q 或 ctrl+c 退出回放
+func isQuitKey(k byte) bool {
+	return k == 'q' || k == 'Q' || k == 3
+}
+
+// handlePlaybackKey 处理暂停与调速按键
+func handlePlaybackKey(k byte, paused bool, speedIndex int) (bool, int) {
+	switch k {
+	case ' ':
+		paused = !paused
+	case '+', '=':
+		speedIndex = min(speedIndex+1, len(Speeds)-1)
+	case '-', '_':
+		speedIndex = max(speedIndex-1, 0)
+	}
+	return paused, speedIndex
+}
+
+// speedIndexOf 返回最接近的速度档位
+func speedIndexOf(speed float64) int {
+	index := 0
+	for i, s := range Speeds {
+		if s <= speed {
+			index = i
+		}
+	}
+	return index
+}
+
+// finishPlayback 重置终端属性；正常播放完毕时显示提示并等待按键后返回
+func finishPlayback(out io.Writer, keys <-chan byte, wait bool) error {
+	fmt.Fprint(out, "\033[0m")
+	if wait {
+		fmt.Fprintf(out, "\r\n%s\r\n", i18n.T(i18n.PlaybackFinished))
+		<-keys
+	}
+	return nil
+}
+
+// newInputReader 返回可取消的标准输入读取器；标准输入不是终端时无法取消，直接读取
+func newInputReader() (cancelreader.CancelReader, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return stdinReader{os.Stdin}, nil
+	}
+	return cancelreader.NewReader(os.Stdin)
+}
+
+// stdinReader 不可取消的标准输入
+type stdinReader struct {
+	*os.File
+}
+
+func (stdinReader) Cancel() bool { return false }
+func (stdinReader) Close() error { return nil }
@@ -0,0 +1,102 @@
+//go:build !windows
+
+package recording
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/creack/pty"
+	"golang.org/x/term"
+)
+
+// Record 在伪终端中运行命令，用户照常交互，同时将终端输出录制到 asciicast v2 文件
+func Record(cmd *exec.Cmd, path, title string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stdinFd := int(os.Stdin.Fd())
+	cols, rows := 80, 24
+	if w, h, err := term.GetSize(stdinFd); err == nil {
+		cols, rows = w, h
+	}
+
+	cast, err := NewWriter(file, Header{
+		Width:     cols,
+		Height:    rows,
+		Timestamp: time.Now().Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	})
+	if err != nil {
+		return err
+	}
+
+	ptmx, err := pty.StartWithSize(cmd, &pty.Winsize{Cols: uint16(cols), Rows: uint16(rows)})
+	if err != nil {
+		return err
+	}
+	defer ptmx.Close()
+
+	// 终端尺寸变化时同步到伪终端并记录
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, syscall.SIGWINCH)
+	defer func() {
+		signal.Stop(resize)
+		close(resize)
+	}()
+	go func() {
+		for range resize {
+			if w, h, err := term.GetSize(stdinFd); err == nil {
+				_ = pty.Setsize(ptmx, &pty.Winsize{Cols: uint16(w), Rows: uint16(h)})
+				_ = cast.Resize(w, h)
+			}
+		}
+	}()
+
+	if term.IsTerminal(stdinFd) {
+		oldState, err := term.MakeRaw(stdinFd)
+		if err != nil {
+			return err
+		}
+		defer term.Restore(stdinFd, oldState)
+	}
+
+	// 使用可取消的读取器转发输入，会话结束后不会继续占用标准输入
+	input, err := newInputReader()
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+	go func() { _, _ = io.Copy(ptmx, input) }()
+
+	// 伪终端关闭时读取会返回 EIO，视为正常结束
+	_, copyErr := io.Copy(io.MultiWriter(os.Stdout, castOutput{cast}), ptmx)
+	input.Cancel()
+
+	err = cmd.Wait()
+	if err == nil && copyErr != nil && !errors.Is(copyErr, syscall.EIO) {
+		err = copyErr
+	}
+	return err
+}
+
+// castOutput 将写入的数据作为输出事件记录
+type castOutput struct {
+	cast *Writer
+}
+
+func (c castOutput) Write(p []byte) (int, error) {
+	if err := c.cast.Output(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
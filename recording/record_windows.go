@@ -0,0 +1,15 @@
+//go:build windows
+
+package recording
+
+import (
+	"fmt"
+	"os/exec"
+
+	"sshgo/i18n"
+)
+
+// Record Windows 上不支持伪终端录制
+func Record(cmd *exec.Cmd, path, title string) error {
+	return fmt.Errorf("%s", i18n.T(i18n.RecordingUnsupported))
+}
@@ -0,0 +1,95 @@
+package recording
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sshgo/xdg"
+)
+
+// 录制文件的日期与时间格式
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "150405"
+)
+
+// Recording 一个录制文件
+type Recording struct {
+	Host string
+	Time time.Time
+	Path string
+	Size int64
+}
+
+// Dir 返回录制文件根目录：<状态目录>/recordings
+func Dir() string {
+	return filepath.Join(xdg.StateDir(), "recordings")
+}
+
+// safeName 将主机别名转换为可用作目录名的字符串
+func safeName(host string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, host)
+}
+
+// NewPath 为主机生成新的录制文件路径：recordings/<host>/<date>/<time>.cast，并创建目录
+func NewPath(host string, t time.Time) (string, error) {
+	dir := filepath.Join(Dir(), safeName(host), t.Format(dateLayout))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	// 同一秒内的多次录制追加序号，避免覆盖
+	path := filepath.Join(dir, t.Format(timeLayout)+".cast")
+	for i := 1; ; i++ {
+		if _, err := os.Stat(path); err != nil {
+			return path, nil
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s-%d.cast", t.Format(timeLayout), i))
+	}
+}
+
+// List 列出录制文件（最新的在前）；host 为空时列出所有主机的录制
+func List(host string) ([]Recording, error) {
+	pattern := filepath.Join(Dir(), "*", "*", "*.cast")
+	if host != "" {
+		pattern = filepath.Join(Dir(), safeName(host), "*", "*.cast")
+	}
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var recordings []Recording
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		date := filepath.Base(filepath.Dir(p))
+		clock, _, _ := strings.Cut(strings.TrimSuffix(filepath.Base(p), ".cast"), "-")
+		t, err := time.ParseInLocation(dateLayout+timeLayout, date+clock, time.Local)
+		if err != nil {
+			t = info.ModTime()
+		}
+		recordings = append(recordings, Recording{
+			Host: filepath.Base(filepath.Dir(filepath.Dir(p))),
+			Time: t,
+			Path: p,
+			Size: info.Size(),
+		})
+	}
+
+	sort.SliceStable(recordings, func(i, j int) bool {
+		return recordings[i].Time.After(recordings[j].Time)
+	})
+	return recordings, nil
+}
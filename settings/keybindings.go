@@ -0,0 +1,213 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"sshgo/i18n"
+)
+
+// KeySettings 按键绑定：profile 选择内置方案，其余各项覆盖单个操作的按键（为空时使用方案中的按键）
+// 按键名与 bubbletea 一致，如 "ctrl+a"、"alt+v"、"pgup"，空格键写作 "space"
+type KeySettings struct {
+	Profile string `toml:"profile"` // default、vim、emacs
+	Mouse   bool   `toml:"mouse"`   // 是否启用鼠标：点击选择、滚轮滚动、双击连接
+
+	Up       []string `toml:"up"`
+	Down     []string `toml:"down"`
+	PageUp   []string `toml:"page_up"`
+	PageDown []string `toml:"page_down"`
+	Top      []string `toml:"top"`
+	Bottom   []string `toml:"bottom"`
+
+	Select []string `toml:"select"`
+	Back   []string `toml:"back"`
+	Quit   []string `toml:"quit"`
+	Search []string `toml:"search"`
+	Help   []string `toml:"help"`
+	Yes    []string `toml:"yes"`
+	No     []string `toml:"no"`
+
+	Mark       []string `toml:"mark"`
+	MarkAll    []string `toml:"mark_all"`
+	Sort       []string `toml:"sort"`
+	Pin        []string `toml:"pin"`
+	History    []string `toml:"history"`
+	Recordings []string `toml:"recordings"`
+	Undo       []string `toml:"undo"`
+	Redo       []string `toml:"redo"`
+	Palette    []string `toml:"palette"` // 命令面板
+
+	SpeedUp   []string `toml:"speed_up"`   // 回放加速
+	SpeedDown []string `toml:"speed_down"` // 回放减速
+	Save      []string `toml:"save"`       // 保存批量执行的输出
+
+	SwitchPane []string `toml:"switch_pane"` // 文件浏览：切换面板
+	Open       []string `toml:"open"`        // 文件浏览：进入目录
+	Parent     []string `toml:"parent"`      // 文件浏览：返回上级目录
+	Copy       []string `toml:"copy"`        // 文件浏览：复制到另一侧
+	Resume     []string `toml:"resume"`      // 文件浏览：断点续传
+}
+
+// defaultKeys 默认方案（与 bubbles 列表的默认按键一致）
+var defaultKeys = map[string][]string{
+	"up":          {"up", "k"},
+	"down":        {"down", "j"},
+	"page_up":     {"left", "h", "pgup", "b", "u"},
+	"page_down":   {"right", "l", "pgdown", "f", "d"},
+	"top":         {"home", "g"},
+	"bottom":      {"end", "G"},
+	"select":      {"enter"},
+	"back":        {"esc"},
+	"quit":        {"q"},
+	"search":      {"/"},
+	"help":        {"?"},
+	"yes":         {"y", "Y"},
+	"no":          {"n", "N"},
+	"mark":        {"space"},
+	"mark_all":    {"ctrl+a"},
+	"sort":        {"s"},
+	"pin":         {"p"},
+	"history":     {"H"},
+	"recordings":  {"R"},
+	"undo":        {"ctrl+z"},
+	"redo":        {"ctrl+y"},
+	"palette":     {"ctrl+p"},
+	"speed_up":    {"+", "="},
+	"speed_down":  {"-"},
+	"save":        {"w"},
+	"switch_pane": {"tab"},
+	"open":        {"enter", "right", "l"},
+	"parent":      {"backspace", "left", "h"},
+	"copy":        {"c", "f5"},
+	"resume":      {"r"},
+}
+
+// keyProfiles 内置方案相对默认方案的差异
+var keyProfiles = map[string]map[string][]string{
+	"default": {},
+	"vim": {
+		"page_up":   {"ctrl+b", "ctrl+u", "pgup"},
+		"page_down": {"ctrl+f", "ctrl+d", "pgdown"},
+		"top":       {"g", "home"},
+		"bottom":    {"G", "end"},
+		"undo":      {"u"},
+		"redo":      {"ctrl+r"},
+	},
+	"emacs": {
+		"up":        {"ctrl+p", "up"},
+		"down":      {"ctrl+n", "down"},
+		"page_up":   {"alt+v", "pgup"},
+		"page_down": {"ctrl+v", "pgdown"},
+		"top":       {"alt+<", "home"},
+		"bottom":    {"alt+>", "end"},
+		"back":      {"ctrl+g", "esc"},
+		"search":    {"ctrl+s", "/"},
+		"mark":      {"ctrl+@", "space"},
+		"undo":      {"ctrl+_", "ctrl+z"},
+		"open":      {"enter", "right", "ctrl+f"},
+		"parent":    {"backspace", "left", "ctrl+b"},
+		"palette":   {"alt+x"},
+	},
+}
+
+// keyScopes 在同一界面中同时生效的操作，同一界面内的按键不能重复
+var keyScopes = [][]string{
+	// 主机列表（也覆盖了操作菜单、历史等列表界面）
+	{"up", "down", "page_up", "page_down", "top", "bottom", "select", "back", "quit", "search", "help",
+		"mark", "mark_all", "sort", "pin", "history", "recordings", "undo", "redo", "palette"},
+	// 确认对话框
+	{"yes", "no", "back", "help"},
+	// 录制列表
+	{"up", "down", "page_up", "page_down", "top", "bottom", "select", "back", "quit", "search", "help",
+		"speed_up", "speed_down"},
+	// 批量执行结果
+	{"up", "down", "save", "back", "quit", "help"},
+	// 文件浏览（翻页键优先级低于进入目录/返回上级，不参与检查）
+	{"up", "down", "open", "parent", "switch_pane", "copy", "resume", "back", "quit"},
+}
+
+// KeyProfiles 返回内置按键方案的名称
+func KeyProfiles() []string {
+	return []string{"default", "vim", "emacs"}
+}
+
+// KeyActions 返回所有可配置的按键操作，顺序与配置文件一致
+func KeyActions() []string {
+	var actions []string
+	t := reflect.TypeOf(KeySettings{})
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type.Kind() == reflect.Slice {
+			actions = append(actions, t.Field(i).Tag.Get("toml"))
+		}
+	}
+	return actions
+}
+
+// normalizeKey 统一按键名的写法：配置文件中的 " " 与 "space" 等价
+func normalizeKey(k string) string {
+	if k != "" && strings.TrimSpace(k) == "" {
+		return "space"
+	}
+	return strings.TrimSpace(k)
+}
+
+// Bindings 返回各操作实际生效的按键：默认方案，叠加所选方案，再叠加单项覆盖
+func (k KeySettings) Bindings() map[string][]string {
+	bindings := make(map[string][]string, len(defaultKeys))
+	for action, keys := range defaultKeys {
+		bindings[action] = keys
+	}
+	for action, keys := range keyProfiles[k.Profile] {
+		bindings[action] = keys
+	}
+
+	v := reflect.ValueOf(k)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		keys, ok := v.Field(i).Interface().([]string)
+		if !ok || len(keys) == 0 {
+			continue
+		}
+		normalized := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if key = normalizeKey(key); key != "" {
+				normalized = append(normalized, key)
+			}
+		}
+		if len(normalized) > 0 {
+			bindings[t.Field(i).Tag.Get("toml")] = normalized
+		}
+	}
+	return bindings
+}
+
+// validate 校验按键方案，并检查同一界面中是否有按键绑定到多个操作
+func (k KeySettings) validate() error {
+	if err := oneOf("keys.profile", k.Profile, KeyProfiles()...); err != nil {
+		return err
+	}
+
+	bindings := k.Bindings()
+	reported := make(map[string]bool)
+	var errs []error
+	for _, scope := range keyScopes {
+		owner := make(map[string]string)
+		for _, action := range scope {
+			for _, key := range bindings[action] {
+				other, ok := owner[key]
+				if !ok {
+					owner[key] = action
+					continue
+				}
+				if id := key + " " + other + " " + action; other != action && !reported[id] {
+					reported[id] = true
+					errs = append(errs, fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingKeyConflict, key, other, action)))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
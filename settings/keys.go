@@ -0,0 +1,263 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sshgo/i18n"
+
+	"github.com/BurntSushi/toml"
+)
+
+var durationType = reflect.TypeOf(Duration{})
+
+// Keys 返回所有配置项的键（如 network.timeout），按字母排序
+func Keys() []string {
+	var keys []string
+	walk(reflect.ValueOf(Defaults()), "", func(key string, _ reflect.Value) {
+		keys = append(keys, key)
+	})
+	sort.Strings(keys)
+	return keys
+}
+
+// walk 遍历配置结构体的叶子字段
+func walk(v reflect.Value, prefix string, fn func(key string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := prefix + t.Field(i).Tag.Get("toml")
+		field := v.Field(i)
+		// 自定义主题等表格形式的配置只能直接编辑配置文件
+		if field.Kind() == reflect.Map {
+			continue
+		}
+		if field.Kind() == reflect.Struct && field.Type() != durationType {
+			walk(field, key+".", fn)
+			continue
+		}
+		fn(key, field)
+	}
+}
+
+// lookup 按键查找字段
+func lookup(v reflect.Value, key string) (reflect.Value, bool) {
+	var found reflect.Value
+	walk(v, "", func(k string, field reflect.Value) {
+		if k == key {
+			found = field
+		}
+	})
+	return found, found.IsValid()
+}
+
+// format 将字段值格式化为命令行显示的字符串
+func format(field reflect.Value) string {
+	switch v := field.Interface().(type) {
+	case Duration:
+		return v.String()
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parse 按字段类型将命令行参数转换为写入配置文件的值
+func parse(field reflect.Value, value string) (any, error) {
+	switch field.Interface().(type) {
+	case Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		return d.String(), nil
+	case []string:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	case bool:
+		return strconv.ParseBool(value)
+	case int:
+		return strconv.Atoi(value)
+	default:
+		return value, nil
+	}
+}
+
+// Get 返回配置项的当前值（包括环境变量覆盖）
+func Get(key string) (string, error) {
+	field, ok := lookup(reflect.ValueOf(Current()), key)
+	if !ok {
+		return "", fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingUnknownKey, key))
+	}
+	return format(field), nil
+}
+
+// Set 校验并写入配置项；value 为空字符串时从配置文件中删除该项，恢复默认值
+// 只修改该配置项所在的行，保留文件中的注释与顺序；无法原地修改时（如写成内联表）重写整个文件
+func Set(key, value string) error {
+	field, ok := lookup(reflect.ValueOf(Defaults()), key)
+	if !ok {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingUnknownKey, key))
+	}
+
+	path := Path()
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingsLoadFailed, path, err))
+	}
+	raw, err := readFile(path)
+	if err != nil {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingsLoadFailed, path, err))
+	}
+
+	// 按 section.key 定位到对应的表
+	parts := strings.Split(key, ".")
+	table := raw
+	for _, section := range parts[:len(parts)-1] {
+		sub, ok := table[section].(map[string]any)
+		if !ok {
+			sub = make(map[string]any)
+			table[section] = sub
+		}
+		table = sub
+	}
+	name := parts[len(parts)-1]
+
+	line := ""
+	if value == "" {
+		delete(table, name)
+	} else {
+		v, err := parse(field, value)
+		if err != nil {
+			return fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingInvalidValue, key, value, err))
+		}
+		table[name] = v
+
+		var buf strings.Builder
+		if err := toml.NewEncoder(&buf).Encode(map[string]any{name: v}); err != nil {
+			return err
+		}
+		line = strings.TrimSpace(buf.String())
+	}
+
+	var buf strings.Builder
+	if err := toml.NewEncoder(&buf).Encode(raw); err != nil {
+		return err
+	}
+	// 写入前按完整配置校验，避免写入无效值
+	want, err := decode(buf.String())
+	if err != nil {
+		return err
+	}
+
+	// 原地修改后的配置与重写的配置含义相同时才使用原地修改的结果
+	output := buf.String()
+	edited := editSetting(string(data), strings.Join(parts[:len(parts)-1], "."), name, line)
+	if got, err := decode(edited); err == nil && reflect.DeepEqual(got, want) {
+		output = edited
+	}
+	return writeFileAtomic(path, []byte(output), 0600)
+}
+
+// editSetting 在配置文本中修改 section 表中的 name 项：line 为新的 "name = value" 行，为空时删除该项
+// 表中没有该项时追加到表的最后一项之后，没有该表时在文件末尾新建
+func editSetting(content, section, name, line string) string {
+	lines := strings.Split(content, "\n")
+	if content == "" {
+		lines = nil
+	}
+
+	current := ""
+	found := section == ""
+	start, end, insert := -1, -1, 0
+	for i := 0; i < len(lines); i++ {
+		t := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(t, "[") {
+			current = tableName(t)
+			if current == section {
+				found = true
+				insert = i + 1
+			}
+			continue
+		}
+		if current != section || t == "" || strings.HasPrefix(t, "#") {
+			continue
+		}
+		insert = i + 1
+		k, v, ok := strings.Cut(t, "=")
+		if !ok || start >= 0 || strings.Trim(strings.TrimSpace(k), `"'`) != name {
+			continue
+		}
+		// 跨行的数组一直到括号闭合
+		start, end = i, i+1
+		for depth := bracketDepth(v); depth > 0 && end < len(lines); end++ {
+			depth += bracketDepth(lines[end])
+		}
+		insert = end
+		i = end - 1
+	}
+
+	switch {
+	case start >= 0 && line == "":
+		lines = append(lines[:start], lines[end:]...)
+	case start >= 0:
+		indent := lines[start][:len(lines[start])-len(strings.TrimLeft(lines[start], " \t"))]
+		lines = append(lines[:start], append([]string{indent + line}, lines[end:]...)...)
+	case line == "":
+		return content
+	case found:
+		lines = append(lines[:insert], append([]string{line}, lines[insert:]...)...)
+	default:
+		// 在文件末尾新建表，与前面的内容之间空一行
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "["+section+"]", line, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// tableName 返回表头行中的表名；数组表（[[...]]）返回无法匹配的名称
+func tableName(header string) string {
+	if strings.HasPrefix(header, "[[") {
+		return "[["
+	}
+	name, _, _ := strings.Cut(strings.TrimPrefix(header, "["), "]")
+	return strings.TrimSpace(name)
+}
+
+// bracketDepth 统计一行中未闭合的方括号数，忽略字符串与注释中的括号
+func bracketDepth(s string) int {
+	depth := 0
+	var quote rune
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return depth
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		}
+	}
+	return depth
+}
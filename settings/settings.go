@@ -0,0 +1,312 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/xdg"
+
+	"github.com/BurntSushi/toml"
+)
+
+// fileName sshgo 配置文件名（位于配置目录）
+const fileName = "config.toml"
+
+// Duration 配置文件中的时长，格式如 "5s"、"500ms"
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalText 解析时长字符串
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// MarshalText 格式化为时长字符串
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Settings sshgo 的全部配置项
+type Settings struct {
+	// 界面语言：auto（自动检测）、zh、en
+	Language string `toml:"language"`
+
+	SSH     SSHSettings     `toml:"ssh"`
+	Network NetworkSettings `toml:"network"`
+	Connect ConnectSettings `toml:"connect"`
+	Batch   BatchSettings   `toml:"batch"`
+	Keys    KeySettings     `toml:"keys"`
+	Theme   ThemeSettings   `toml:"theme"`
+}
+
+// SSHSettings ssh 配置读取与备份
+type SSHSettings struct {
+	ConfigPaths []string `toml:"config_paths"` // 读取的配置文件，为空时使用各平台默认路径
+	KnownHosts  bool     `toml:"known_hosts"`  // 是否将 known_hosts 中的主机加入列表
+	MaxBackups  int      `toml:"max_backups"`  // 每个文件保留的备份数
+
+	WatchInterval Duration `toml:"watch_interval"` // 检查配置文件变化的间隔，0 表示不检查
+}
+
+// NetworkSettings 网络诊断
+type NetworkSettings struct {
+	Protocol       string   `toml:"protocol"`        // 延迟测试协议
+	LatencySamples int      `toml:"latency_samples"` // 延迟测试次数
+	Timeout        Duration `toml:"timeout"`         // 单次连接超时
+	TraceMaxHops   int      `toml:"trace_max_hops"`  // 路由追踪最大跳数
+	TraceTimeout   Duration `toml:"trace_timeout"`   // 路由追踪每跳超时
+}
+
+// ConnectSettings 连接方式
+type ConnectSettings struct {
+	Launch string `toml:"launch"` // 在 tmux/screen 中的打开方式：current、window、pane
+	Record bool   `toml:"record"` // 是否录制所有连接
+}
+
+// BatchSettings 批量操作
+type BatchSettings struct {
+	RunWorkers   int      `toml:"run_workers"`   // 并发执行命令的并发数
+	CheckWorkers int      `toml:"check_workers"` // 可达性检查的并发数
+	CheckTimeout Duration `toml:"check_timeout"` // 可达性检查超时
+}
+
+// Defaults 返回默认配置
+func Defaults() Settings {
+	return Settings{
+		Language: "auto",
+		SSH: SSHSettings{
+			KnownHosts:    true,
+			MaxBackups:    10,
+			WatchInterval: Duration{2 * time.Second},
+		},
+		Network: NetworkSettings{
+			Protocol:       "tcp",
+			LatencySamples: 5,
+			Timeout:        Duration{5 * time.Second},
+			TraceMaxHops:   30,
+			TraceTimeout:   Duration{2 * time.Second},
+		},
+		Connect: ConnectSettings{
+			Launch: "current",
+		},
+		Batch: BatchSettings{
+			RunWorkers:   8,
+			CheckWorkers: 16,
+			CheckTimeout: Duration{5 * time.Second},
+		},
+		Keys: KeySettings{
+			Profile: "default",
+			Mouse:   true,
+		},
+		Theme: ThemeSettings{
+			Name: "auto",
+		},
+	}
+}
+
+// Path 返回配置文件路径
+func Path() string {
+	return filepath.Join(xdg.ConfigDir(), fileName)
+}
+
+// oneOf 检查取值是否在允许的范围内
+func oneOf(key, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingInvalidChoice, key, value, strings.Join(allowed, ", ")))
+}
+
+// atLeast 检查数值下限
+func atLeast(key string, value, min int) error {
+	if value < min {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingTooSmall, key, value, min))
+	}
+	return nil
+}
+
+// positive 检查时长为正
+func positive(key string, d Duration) error {
+	if d.Duration <= 0 {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingNotPositive, key, d.Duration))
+	}
+	return nil
+}
+
+// notNegative 检查时长不为负
+func notNegative(key string, d Duration) error {
+	if d.Duration < 0 {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingNegative, key, d.Duration))
+	}
+	return nil
+}
+
+// Validate 校验配置取值
+func (s Settings) Validate() error {
+	return errors.Join(
+		oneOf("language", s.Language, "auto", "zh", "en"),
+		atLeast("ssh.max_backups", s.SSH.MaxBackups, 1),
+		notNegative("ssh.watch_interval", s.SSH.WatchInterval),
+		oneOf("network.protocol", s.Network.Protocol, "tcp"),
+		atLeast("network.latency_samples", s.Network.LatencySamples, 1),
+		positive("network.timeout", s.Network.Timeout),
+		atLeast("network.trace_max_hops", s.Network.TraceMaxHops, 1),
+		positive("network.trace_timeout", s.Network.TraceTimeout),
+		oneOf("connect.launch", s.Connect.Launch, "current", "window", "pane"),
+		atLeast("batch.run_workers", s.Batch.RunWorkers, 1),
+		atLeast("batch.check_workers", s.Batch.CheckWorkers, 1),
+		positive("batch.check_timeout", s.Batch.CheckTimeout),
+		s.Keys.validate(),
+		s.Theme.validate(),
+	)
+}
+
+// readFile 读取配置文件中的原始键值（不存在时为空），用于修改时保留未涉及的配置项
+func readFile(path string) (map[string]any, error) {
+	raw := make(map[string]any)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return raw, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// writeFileAtomic 先写入同目录的临时文件再重命名，避免写入中断时留下不完整的配置文件
+// （settings 不能依赖 ssh 包，做法与 ssh.WriteFileAtomic 相同）
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	cleanup := func(err error) error {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// decode 在默认配置的基础上解析配置文本，拒绝未知的配置项
+func decode(data string) (Settings, error) {
+	s := Defaults()
+	md, err := toml.Decode(data, &s)
+	if err != nil {
+		return Defaults(), err
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return Defaults(), fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingUnknownKey, undecoded[0].String()))
+	}
+	if err := s.Validate(); err != nil {
+		return Defaults(), err
+	}
+	return s, nil
+}
+
+// Load 读取配置文件并应用环境变量覆盖；文件不存在时使用默认配置
+// 配置文件有误时返回默认配置和错误
+func Load() (Settings, error) {
+	s := Defaults()
+	data, err := os.ReadFile(Path())
+	if os.IsNotExist(err) {
+		return applyEnv(s), nil
+	}
+	if err != nil {
+		return applyEnv(s), err
+	}
+	s, err = decode(string(data))
+	if err != nil {
+		err = fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingsLoadFailed, Path(), err))
+	}
+	return applyEnv(s), err
+}
+
+// applyEnv 环境变量优先于配置文件：SSHGO_LANG、SSHGO_LAUNCH、SSHGO_RECORD
+func applyEnv(s Settings) Settings {
+	if v := strings.ToLower(os.Getenv("SSHGO_LANG")); v != "" {
+		switch {
+		case strings.HasPrefix(v, "zh") || v == "cn" || v == "chinese":
+			s.Language = "zh"
+		case strings.HasPrefix(v, "en") || v == "english":
+			s.Language = "en"
+		}
+	}
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("SSHGO_LAUNCH"))); v != "" {
+		if oneOf("connect.launch", v, "current", "window", "pane") == nil {
+			s.Connect.Launch = v
+		}
+	}
+	if v, err := strconv.ParseBool(os.Getenv("SSHGO_RECORD")); err == nil {
+		s.Connect.Record = v
+	}
+	return s
+}
+
+var (
+	current  Settings
+	loadErr  error
+	loadOnce sync.Once
+)
+
+// Current 返回当前配置（首次调用时读取配置文件并设置界面语言）
+func Current() Settings {
+	loadOnce.Do(func() {
+		current, loadErr = Load()
+		if current.Language != "auto" {
+			i18n.SetLanguage(i18n.Language(current.Language))
+		}
+	})
+	return current
+}
+
+// Init 读取配置并返回配置文件中的错误，供程序启动时提示
+func Init() error {
+	Current()
+	return loadErr
+}
@@ -0,0 +1,266 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecode(t *testing.T) {
+	s, err := decode(`
+language = "en"
+
+[network]
+timeout = "500ms"
+latency_samples = 3
+
+[connect]
+launch = "pane"
+`)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if s.Language != "en" || s.Network.Timeout.Duration != 500*time.Millisecond ||
+		s.Network.LatencySamples != 3 || s.Connect.Launch != "pane" {
+		t.Errorf("unexpected settings: %+v", s)
+	}
+	// 未出现的配置项保持默认值
+	if s.Network.TraceMaxHops != 30 || s.SSH.MaxBackups != 10 {
+		t.Errorf("defaults not kept: %+v", s)
+	}
+
+	invalid := []string{
+		`language = "fr"`,
+		"[network]\ntimeout = \"-1s\"",
+		"[batch]\nrun_workers = 0",
+		"[network]\ntimeout = \"soon\"",
+		"[ssh]\nunknown = 1",
+	}
+	for _, data := range invalid {
+		if _, err := decode(data); err == nil {
+			t.Errorf("decode(%q) succeeded, want error", data)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", os.Getenv("XDG_CONFIG_HOME"))
+	for _, env := range []string{"SSHGO_LANG", "SSHGO_LAUNCH", "SSHGO_RECORD"} {
+		t.Setenv(env, "")
+	}
+
+	if err := Set("network.timeout", "2s"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := Set("ssh.config_paths", "~/a, ~/b"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := Set("batch.run_workers", "0"); err == nil {
+		t.Error("Set accepted an invalid value")
+	}
+	if err := Set("no.such_key", "1"); err == nil {
+		t.Error("Set accepted an unknown key")
+	}
+	if err := Set("keys.mouse", "false"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	s, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if s.Network.Timeout.Duration != 2*time.Second {
+		t.Errorf("timeout = %v, want 2s", s.Network.Timeout)
+	}
+	if got := strings.Join(s.SSH.ConfigPaths, ","); got != "~/a,~/b" {
+		t.Errorf("config_paths = %q", got)
+	}
+	if s.Keys.Mouse {
+		t.Error("keys.mouse = true, want false")
+	}
+
+	// 空值删除配置项，恢复默认
+	if err := Set("network.timeout", ""); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if s, _ = Load(); s.Network.Timeout.Duration != 5*time.Second {
+		t.Errorf("timeout = %v after reset, want 5s", s.Network.Timeout)
+	}
+
+	info, err := os.Stat(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "sshgo", fileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 && os.PathSeparator == '/' {
+		t.Errorf("config file mode = %v, want 0600", perm)
+	}
+
+	// 环境变量优先于配置文件
+	if err := Set("connect.launch", "window"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	t.Setenv("SSHGO_LAUNCH", "pane")
+	if s, _ = Load(); s.Connect.Launch != "pane" {
+		t.Errorf("launch = %q, want env override pane", s.Connect.Launch)
+	}
+}
+
+// Set 只修改对应的行，保留注释、顺序与其他配置项
+func TestSetKeepsComments(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", os.Getenv("XDG_CONFIG_HOME"))
+	path := Path()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	content := `# sshgo 配置
+language = "en"
+
+[network]
+# 慢速网络
+timeout = "10s"
+protocol = "tcp"
+
+[ssh]
+config_paths = [
+  "~/.ssh/config",
+  "~/.ssh/work",
+]
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		key, value, want string
+	}{
+		{"network.timeout", "3s", strings.Replace(content, `timeout = "10s"`, `timeout = "3s"`, 1)},
+		{"ssh.config_paths", "~/a", strings.Replace(content, "config_paths = [\n  \"~/.ssh/config\",\n  \"~/.ssh/work\",\n]", `config_paths = ["~/a"]`, 1)},
+		{"network.protocol", "", strings.Replace(content, "protocol = \"tcp\"\n", "", 1)},
+		{"network.latency_samples", "3", strings.Replace(content, "protocol = \"tcp\"\n", "protocol = \"tcp\"\nlatency_samples = 3\n", 1)},
+		{"batch.run_workers", "4", content + "\n[batch]\nrun_workers = 4\n"},
+	}
+	for _, step := range steps {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := Set(step.key, step.value); err != nil {
+			t.Fatalf("Set(%s, %q): %v", step.key, step.value, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != step.want {
+			t.Errorf("Set(%s, %q) wrote:\n%s\nwant:\n%s", step.key, step.value, data, step.want)
+		}
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("APPDATA", os.Getenv("XDG_CONFIG_HOME"))
+	if _, err := Load(); err != nil {
+		t.Errorf("Load without config file: %v", err)
+	}
+}
+
+func TestKeyBindings(t *testing.T) {
+	for _, profile := range KeyProfiles() {
+		if err := (KeySettings{Profile: profile}).validate(); err != nil {
+			t.Errorf("profile %s: %v", profile, err)
+		}
+	}
+
+	s, err := decode(`
+[keys]
+profile = "vim"
+quit = ["ctrl+q"]
+mark = [" ", "x"]
+`)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	bindings := s.Keys.Bindings()
+	if got := strings.Join(bindings["undo"], ","); got != "u" {
+		t.Errorf("vim undo = %q, want u", got)
+	}
+	if got := strings.Join(bindings["quit"], ","); got != "ctrl+q" {
+		t.Errorf("quit = %q, want override ctrl+q", got)
+	}
+	if got := strings.Join(bindings["mark"], ","); got != "space,x" {
+		t.Errorf("mark = %q, want space,x", got)
+	}
+	if got := strings.Join(bindings["select"], ","); got != "enter" {
+		t.Errorf("select = %q, want default enter", got)
+	}
+
+	invalid := []string{
+		"[keys]\nprofile = \"nano\"",
+		"[keys]\nsort = [\"p\"]",                              // 与 pin 冲突
+		"[keys]\nyes = [\"esc\"]",                             // 确认对话框中与 back 冲突
+		"[keys]\nresume = [\"tab\"]",                          // 文件浏览中与 switch_pane 冲突
+		"[keys]\nprofile = \"emacs\"\npalette = [\"ctrl+p\"]", // emacs 方案中与 up 冲突
+	}
+	for _, data := range invalid {
+		if _, err := decode(data); err == nil {
+			t.Errorf("decode(%q) succeeded, want conflict error", data)
+		}
+	}
+
+	// 不同界面中的同一按键不算冲突
+	if _, err := decode("[keys]\nsave = [\"s\"]"); err != nil {
+		t.Errorf("save = s: %v", err)
+	}
+}
+
+func TestThemeColors(t *testing.T) {
+	light := func() bool { return false }
+
+	if got := (ThemeSettings{Name: "auto"}).Colors(light); got != builtinThemes["light"] {
+		t.Errorf("auto on light background = %+v", got)
+	}
+	if got := (ThemeSettings{Name: "high-contrast"}).Colors(func() bool {
+		t.Error("background detected for a fixed theme")
+		return true
+	}); got != builtinThemes["high-contrast"] {
+		t.Errorf("high-contrast = %+v", got)
+	}
+
+	s, err := decode(`
+[theme]
+name = "mine"
+
+[theme.custom.mine]
+base = "dark"
+title = "#ff8800"
+error = "160"
+`)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	c := s.Theme.Colors(light)
+	if c.Title != "#ff8800" || c.Error != "160" || c.Success != builtinThemes["dark"].Success || c.Base != "" {
+		t.Errorf("custom theme = %+v", c)
+	}
+	for _, key := range Keys() {
+		if strings.HasPrefix(key, "theme.custom") {
+			t.Errorf("Keys() lists table %s", key)
+		}
+	}
+
+	invalid := []string{
+		"[theme]\nname = \"solarized\"",
+		"[theme.custom.x]\nbase = \"sepia\"",
+		"[theme.custom.x]\ntitle = \"orange\"",
+		"[theme.custom.x]\ntitle = \"256\"",
+	}
+	for _, data := range invalid {
+		if _, err := decode(data); err == nil {
+			t.Errorf("decode(%q) succeeded, want error", data)
+		}
+	}
+}
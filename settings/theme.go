@@ -0,0 +1,156 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"sshgo/i18n"
+)
+
+// ThemeSettings 界面主题：name 选择内置主题或 custom 中的自定义主题
+type ThemeSettings struct {
+	Name   string                 `toml:"name"`   // auto、dark、light、high-contrast 或自定义主题名
+	Custom map[string]ThemeColors `toml:"custom"` // 自定义主题，写作 [theme.custom.<名称>]
+}
+
+// ThemeColors 主题颜色，取值为 "#rrggbb"、"#rgb" 或 0-255 的终端颜色编号，为空表示终端默认颜色
+// 自定义主题中未设置的颜色取自 base 指定的内置主题
+type ThemeColors struct {
+	Base string `toml:"base"` // 仅用于自定义主题：继承的内置主题，默认 auto
+
+	Title        string `toml:"title"`          // 标题、选中的面板
+	Text         string `toml:"text"`           // 列表项
+	Muted        string `toml:"muted"`          // 状态、帮助和说明文字
+	Accent       string `toml:"accent"`         // diff 区块标记、进度动画
+	Border       string `toml:"border"`         // 边框
+	Selected     string `toml:"selected"`       // 列表中选中的项
+	Success      string `toml:"success"`        // 成功消息、新增行
+	Warning      string `toml:"warning"`        // 警告
+	Error        string `toml:"error"`          // 错误消息、删除行
+	TitleBar     string `toml:"title_bar"`      // 列表标题背景
+	TitleBarText string `toml:"title_bar_text"` // 列表标题文字
+}
+
+// builtinThemes 内置主题（dark 与原有界面颜色一致）
+var builtinThemes = map[string]ThemeColors{
+	"dark": {
+		Title:        "170",
+		Text:         "#dddddd",
+		Muted:        "241",
+		Accent:       "39",
+		Border:       "62",
+		Selected:     "#EE6FF8",
+		Success:      "82",
+		Warning:      "208",
+		Error:        "196",
+		TitleBar:     "62",
+		TitleBarText: "230",
+	},
+	"light": {
+		Title:        "127",
+		Text:         "#1a1a1a",
+		Muted:        "243",
+		Accent:       "25",
+		Border:       "61",
+		Selected:     "163",
+		Success:      "28",
+		Warning:      "166",
+		Error:        "160",
+		TitleBar:     "61",
+		TitleBarText: "255",
+	},
+	"high-contrast": {
+		Title:        "11",
+		Text:         "15",
+		Muted:        "7",
+		Accent:       "14",
+		Border:       "15",
+		Selected:     "11",
+		Success:      "10",
+		Warning:      "11",
+		Error:        "9",
+		TitleBar:     "11",
+		TitleBarText: "0",
+	},
+}
+
+// colorPattern 十六进制颜色
+var colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// BuiltinThemes 返回内置主题的名称（auto 按终端背景选择 dark 或 light）
+func BuiltinThemes() []string {
+	return []string{"auto", "dark", "light", "high-contrast"}
+}
+
+// customNames 返回自定义主题的名称，按字母排序
+func (t ThemeSettings) customNames() []string {
+	names := make([]string, 0, len(t.Custom))
+	for name := range t.Custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// builtin 返回内置主题的颜色，auto 时调用 isDark 检测终端背景
+func builtin(name string, isDark func() bool) ThemeColors {
+	if name == "" || name == "auto" {
+		name = "light"
+		if isDark() {
+			name = "dark"
+		}
+	}
+	return builtinThemes[name]
+}
+
+// Colors 返回所选主题的颜色；isDark 仅在需要自动检测终端背景时调用
+func (t ThemeSettings) Colors(isDark func() bool) ThemeColors {
+	custom, ok := t.Custom[t.Name]
+	if !ok {
+		return builtin(t.Name, isDark)
+	}
+
+	// 自定义主题在基础主题上覆盖设置了的颜色
+	colors := builtin(custom.Base, isDark)
+	base := reflect.ValueOf(&colors).Elem()
+	override := reflect.ValueOf(custom)
+	for i := 0; i < base.NumField(); i++ {
+		if v := override.Field(i).String(); v != "" {
+			base.Field(i).SetString(v)
+		}
+	}
+	colors.Base = ""
+	return colors
+}
+
+// validColor 检查颜色取值
+func validColor(key, value string) error {
+	if value == "" || colorPattern.MatchString(value) {
+		return nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return nil
+	}
+	return fmt.Errorf("%s", i18n.TWithArgs(i18n.SettingInvalidColor, key, value))
+}
+
+// validate 校验主题名称与自定义主题的颜色
+func (t ThemeSettings) validate() error {
+	errs := []error{oneOf("theme.name", t.Name, append(BuiltinThemes(), t.customNames()...)...)}
+	for _, name := range t.customNames() {
+		colors := t.Custom[name]
+		prefix := "theme.custom." + name + "."
+		if colors.Base != "" {
+			errs = append(errs, oneOf(prefix+"base", colors.Base, BuiltinThemes()...))
+		}
+		v := reflect.ValueOf(colors)
+		for i := 1; i < v.NumField(); i++ {
+			errs = append(errs, validColor(prefix+v.Type().Field(i).Tag.Get("toml"), v.Field(i).String()))
+		}
+	}
+	return errors.Join(errs...)
+}
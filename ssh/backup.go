@@ -0,0 +1,193 @@
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/settings"
+)
+
+// 备份文件名中的时间戳格式（可按字典序排序）
+const backupTimeLayout = "20060102-150405.000"
+
+// Backup 表示某个文件的一次备份
+type Backup struct {
+	Path   string    // 备份文件路径
+	Source string    // 被备份的原始文件路径
+	Time   time.Time // 备份时间
+}
+
+// GetBackupDir 获取备份目录（与 SSH 配置位于同一目录下）
+func GetBackupDir() string {
+	return filepath.Join(filepath.Dir(GetSSHConfigPath()), ".sshgo_backups")
+}
+
+// WriteFileAtomic 原子地写入文件：先写临时文件再重命名
+// 若目标文件已存在则沿用其权限，否则使用 perm
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	// 任何一步失败都清理临时文件
+	cleanup := func(err error) error {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return cleanup(err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// WriteFileWithBackup 先备份原文件，再原子写入新内容，并记录写入的内容用于检测外部修改
+func WriteFileWithBackup(path string, data []byte) error {
+	if _, err := CreateBackup(path); err != nil {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.BackupFailed, err))
+	}
+	if err := WriteFileAtomic(path, data, 0600); err != nil {
+		return err
+	}
+	rememberFile(path, string(data))
+	return nil
+}
+
+// CreateBackup 为指定文件创建带时间戳的备份，并清理超出数量上限的旧备份
+// 文件不存在时不做任何事，返回空的 Backup
+func CreateBackup(source string) (Backup, error) {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Backup{}, nil
+		}
+		return Backup{}, err
+	}
+
+	dir := GetBackupDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return Backup{}, err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s.%s.bak", filepath.Base(source), now.Format(backupTimeLayout))
+	backup := Backup{Path: filepath.Join(dir, name), Source: source, Time: now}
+	if err := WriteFileAtomic(backup.Path, data, 0600); err != nil {
+		return Backup{}, err
+	}
+
+	// 轮换：只保留最新的 ssh.max_backups 个
+	backups, err := ListBackups(source)
+	if err != nil {
+		return backup, nil
+	}
+	for i := settings.Current().SSH.MaxBackups; i < len(backups); i++ {
+		os.Remove(backups[i].Path)
+	}
+
+	return backup, nil
+}
+
+// ListBackups 列出指定文件的所有备份，按时间从新到旧排序
+func ListBackups(source string) ([]Backup, error) {
+	entries, err := os.ReadDir(GetBackupDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	prefix := filepath.Base(source) + "."
+	var backups []Backup
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".bak") {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".bak")
+		t, err := time.ParseInLocation(backupTimeLayout, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Backup{
+			Path:   filepath.Join(GetBackupDir(), name),
+			Source: source,
+			Time:   t,
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups, nil
+}
+
+// PreviewRestore 生成恢复备份时的 diff（当前内容 -> 备份内容）
+func PreviewRestore(b Backup) (string, error) {
+	current, err := readFileOrEmpty(b.Source)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		return "", err
+	}
+	return UnifiedDiff(b.Source, b.Path, current, string(data)), nil
+}
+
+// RestoreBackup 用备份内容覆盖原文件（覆盖前同样会备份当前内容）
+func RestoreBackup(b Backup) error {
+	data, err := os.ReadFile(b.Path)
+	if err != nil {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.RestoreBackupFailed, err))
+	}
+	if err := WriteFileWithBackup(b.Source, data); err != nil {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.RestoreBackupFailed, err))
+	}
+	return nil
+}
+
+// readFileOrEmpty 读取文件，文件不存在时返回空字符串
+func readFileOrEmpty(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,129 @@
+package ssh
+
+import (
+	"fmt"
+	"strings"
+
+	"sshgo/i18n"
+)
+
+// HostAliases 提取主机别名列表，用于批量操作
+func HostAliases(hosts []SSHHost) []string {
+	aliases := make([]string, len(hosts))
+	for i, h := range hosts {
+		aliases[i] = h.Host
+	}
+	return aliases
+}
+
+// UpdateHostsDirective 批量更新多个主机的同一指令，整个配置文件只写入一次
+func UpdateHostsDirective(hosts []string, directive, value string) error {
+	configPath := GetSSHConfigPath()
+	content, err := readFileOrEmpty(configPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T(i18n.ReadConfigFileFailed), err)
+	}
+
+	output := content
+	for _, host := range hosts {
+		output = updateHostDirectiveContent(output, host, directive, value)
+	}
+	if output == content {
+		return nil
+	}
+
+	if err := WriteFileWithBackup(configPath, []byte(output)); err != nil {
+		return fmt.Errorf(i18n.T(i18n.WriteConfigFileFailed), err)
+	}
+	return nil
+}
+
+// removeHostsContent 计算批量删除主机后的 config 与 known_hosts 内容
+func removeHostsContent(hosts []string) (configOld, configNew, knownOld, knownNew string, err error) {
+	configOld, err = readFileOrEmpty(GetSSHConfigPath())
+	if err != nil {
+		return "", "", "", "", fmt.Errorf(i18n.T(i18n.ReadConfigFileFailed), err)
+	}
+	knownOld, err = readFileOrEmpty(GetKnownHostsPath())
+	if err != nil {
+		return "", "", "", "", fmt.Errorf(i18n.T(i18n.ReadKnownHostsFailed), err)
+	}
+
+	configNew, knownNew = configOld, knownOld
+	for _, host := range hosts {
+		configNew = removeHostBlock(configNew, host)
+		knownNew = removeKnownHostEntries(knownNew, host)
+	}
+	return configOld, configNew, knownOld, knownNew, nil
+}
+
+// RemoveHosts 批量删除多个主机的配置与 known_hosts 记录，每个文件只写入一次
+func RemoveHosts(hosts []string) error {
+	configOld, configNew, knownOld, knownNew, err := removeHostsContent(hosts)
+	if err != nil {
+		return err
+	}
+
+	if configNew != configOld {
+		if err := WriteFileWithBackup(GetSSHConfigPath(), []byte(configNew)); err != nil {
+			return fmt.Errorf(i18n.T(i18n.WriteConfigFileFailed), err)
+		}
+	}
+	if knownNew != knownOld {
+		if err := WriteFileWithBackup(GetKnownHostsPath(), []byte(knownNew)); err != nil {
+			return fmt.Errorf(i18n.T(i18n.WriteKnownHostsFailed), err)
+		}
+	}
+	return nil
+}
+
+// PreviewRemoveHosts 预览批量删除主机时的变化（统一 diff 格式）
+func PreviewRemoveHosts(hosts []string) (string, error) {
+	configOld, configNew, knownOld, knownNew, err := removeHostsContent(hosts)
+	if err != nil {
+		return "", err
+	}
+	configPath, knownHostsPath := GetSSHConfigPath(), GetKnownHostsPath()
+	return UnifiedDiff(configPath, configPath, configOld, configNew) +
+		UnifiedDiff(knownHostsPath, knownHostsPath, knownOld, knownNew), nil
+}
+
+// ExportHosts 导出主机配置块；配置文件中没有对应块的主机（如仅来自 known_hosts）按字段生成
+func ExportHosts(hosts []SSHHost) (string, error) {
+	content, err := readFileOrEmpty(GetSSHConfigPath())
+	if err != nil {
+		return "", fmt.Errorf(i18n.T(i18n.ReadConfigFileFailed), err)
+	}
+	blocks := splitConfigBlocks(content)
+
+	var out []string
+	for _, h := range hosts {
+		var lines []string
+		if index := findHostBlock(blocks, h.Host); index >= 0 {
+			b := blocks[index]
+			lines = append([]string{strings.TrimSpace(b.header)}, b.body...)
+		} else {
+			lines = append(lines, "Host "+h.Host)
+			if h.HostName != "" {
+				lines = append(lines, "    HostName "+h.HostName)
+			}
+			if h.User != "" {
+				lines = append(lines, "    User "+h.User)
+			}
+			if h.Port != "" && h.Port != "22" {
+				lines = append(lines, "    Port "+h.Port)
+			}
+			if h.KeyFile != "" {
+				lines = append(lines, "    IdentityFile "+h.KeyFile)
+			}
+			if h.ProxyJump != "" {
+				lines = append(lines, "    ProxyJump "+h.ProxyJump)
+			}
+		}
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		out = append(out, strings.Join(lines, "\n"))
+	}
+	return strings.Join(out, "\n\n") + "\n", nil
+}
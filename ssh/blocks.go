@@ -0,0 +1,94 @@
+package ssh
+
+import (
+	"strings"
+)
+
+// configBlock 配置文件中以 Host 行开始的一个配置块
+type configBlock struct {
+	header string   // 原始 Host 行
+	hosts  []string // Host 行里的所有主机模式
+	body   []string // 不包含 header 的后续行（直到下一个 Host 行 / 文件结束）
+}
+
+// splitConfigBlocks 将配置文本切分为多个块
+func splitConfigBlocks(content string) []configBlock {
+	var blocks []configBlock
+	var current *configBlock
+
+	flush := func() {
+		if current != nil {
+			blocks = append(blocks, *current)
+			current = nil
+		}
+	}
+
+	for _, raw := range strings.Split(content, "\n") {
+		trim := strings.TrimSpace(raw)
+		if strings.HasPrefix(strings.ToLower(trim), "host ") {
+			// 新的 block
+			flush()
+			fields := strings.Fields(trim)
+			var hostSpecs []string
+			if len(fields) > 1 {
+				hostSpecs = fields[1:]
+			}
+			current = &configBlock{header: raw, hosts: hostSpecs}
+			continue
+		}
+		if current == nil {
+			// 文件可能前面有非 Host 行（不标准），我们直接跳过或放入匿名 block
+			if raw == "" && len(blocks) == 0 {
+				// 顶部空行忽略
+				continue
+			}
+			// 放入一个无 header 的 block（用于保留可能的注释）
+			current = &configBlock{header: "", hosts: nil}
+		}
+		current.body = append(current.body, raw)
+	}
+	flush()
+
+	return blocks
+}
+
+// joinConfigBlocks 将配置块重新拼接为文本，并清理多余的末尾空行
+func joinConfigBlocks(blocks []configBlock) string {
+	var out []string
+	for _, b := range blocks {
+		if b.header != "" {
+			out = append(out, b.header)
+		}
+		out = append(out, b.body...)
+	}
+
+	for len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// findHostBlock 查找包含指定主机的配置块下标，未找到返回 -1
+// host 可以是 Host 行中的某一个模式，也可以是完整的模式列表（如 "web-1 web1.example.com"）
+func findHostBlock(blocks []configBlock, host string) int {
+	full := strings.Join(strings.Fields(host), " ")
+	for i, b := range blocks {
+		if len(b.hosts) == 0 {
+			continue
+		}
+		if strings.Join(b.hosts, " ") == full {
+			return i
+		}
+		for _, h := range b.hosts {
+			if h == host { // 精确匹配
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// hostHeaderIndent 返回 Host 行原有的缩进
+func hostHeaderIndent(header string) string {
+	return header[:len(header)-len(strings.TrimLeft(header, " \t"))]
+}
@@ -2,6 +2,7 @@ package ssh
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,12 +10,20 @@ import (
 	"strings"
 
 	"sshgo/i18n"
+	"sshgo/settings"
 )
 
-// GetAllConfigPaths 获取所有可能的配置文件路径
+// GetAllConfigPaths 获取所有可能的配置文件路径；配置了 ssh.config_paths 时使用配置的路径
 func GetAllConfigPaths() []string {
 	var configPaths []string
 
+	if paths := settings.Current().SSH.ConfigPaths; len(paths) > 0 {
+		for _, p := range paths {
+			configPaths = append(configPaths, expandHome(p))
+		}
+		return configPaths
+	}
+
 	if runtime.GOOS == "windows" {
 		home := os.Getenv("USERPROFILE")
 		configPaths = append(configPaths, filepath.Join(home, ".ssh", "config"))
@@ -43,14 +52,20 @@ func GetSSHConfigPath() string {
 		}
 	}
 
-	// 如果没有找到配置文件，返回默认路径
+	// 如果没有找到配置文件，返回第一个路径（默认为 ~/.ssh/config）
+	return configPaths[0]
+}
+
+// expandHome 展开路径开头的 ~
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+	home := os.Getenv("HOME")
 	if runtime.GOOS == "windows" {
-		home := os.Getenv("USERPROFILE")
-		return filepath.Join(home, ".ssh", "config")
-	} else {
-		home := os.Getenv("HOME")
-		return filepath.Join(home, ".ssh", "config")
+		home = os.Getenv("USERPROFILE")
 	}
+	return filepath.Join(home, path[1:])
 }
 
 // GetKnownHostsPath 获取known_hosts文件路径
@@ -92,8 +107,12 @@ func ParseSSHConfig(configPath string) ([]SSHHost, error) {
 		}
 	}
 
-	// 从known_hosts文件中读取主机信息
-	knownHosts, err := parseKnownHosts()
+	// 从known_hosts文件中读取主机信息（可通过 ssh.known_hosts 关闭）
+	var knownHosts []SSHHost
+	var err error
+	if settings.Current().SSH.KnownHosts {
+		knownHosts, err = parseKnownHosts()
+	}
 	if err != nil {
 		// 如果读取known_hosts文件出错，只打印警告信息，不中断程序
 		fmt.Printf(i18n.T(i18n.ReadKnownHostsWarning)+"\n", err)
@@ -117,25 +136,71 @@ func ParseSSHConfig(configPath string) ([]SSHHost, error) {
 		}
 	}
 
+	// 未配置 User 的主机记录从匹配的通配符块继承的用户，连接时使用
+	blocks := append([]SSHHost(nil), hosts...)
+	for i, h := range hosts {
+		if !isPattern(h.Host) {
+			hosts[i] = ApplyUserDefaults(h, blocks)
+		}
+	}
+
 	return hosts, nil
 }
 
-// parseSingleConfigFile 解析单个配置文件
+// maxIncludeDepth Include 的最大嵌套层数（与 OpenSSH 相同）
+const maxIncludeDepth = 16
+
+// parseSingleConfigFile 解析单个配置文件，Include 的文件中的主机追加在该文件的主机之后
 func parseSingleConfigFile(configPath string) ([]SSHHost, error) {
+	return parseConfigFile(configPath, filepath.Dir(configPath), 0)
+}
+
+// includedFiles 展开 Include 指令中的路径：支持 ~ 与通配符，相对路径相对于顶层配置文件所在目录
+func includedFiles(value, baseDir string) []string {
+	var files []string
+	for _, pattern := range strings.Fields(value) {
+		pattern = expandHome(pattern)
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(baseDir, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			continue
+		}
+		files = append(files, matches...)
+	}
+	return files
+}
+
+// parseConfigFile 解析配置文件并记录读取到的内容，depth 为 Include 的嵌套层数
+func parseConfigFile(configPath, baseDir string, depth int) ([]SSHHost, error) {
 	var hosts []SSHHost
 
-	file, err := os.Open(configPath)
+	data, err := os.ReadFile(configPath)
 	if err != nil {
+		if os.IsNotExist(err) {
+			rememberFile(configPath, "")
+		}
 		return hosts, err
 	}
-	defer file.Close()
+	rememberFile(configPath, string(data))
 
-	scanner := bufio.NewScanner(file)
+	var included []SSHHost
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 	var currentHost *SSHHost
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 
+		// sshgo 元数据注释（标签/分组）
+		if currentHost != nil && isMetaLine(line) {
+			if meta, err := ParseHostMeta(line); err == nil {
+				currentHost.Tags = meta.Tags
+				currentHost.Group = meta.Group
+			}
+			continue
+		}
+
 		// 跳过空行和注释
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
@@ -150,14 +215,25 @@ func parseSingleConfigFile(configPath string) ([]SSHHost, error) {
 		key := strings.ToLower(parts[0])
 		value := strings.Join(parts[1:], " ")
 
-		if key == "host" {
+		if key == "include" {
+			if depth >= maxIncludeDepth {
+				continue
+			}
+			for _, path := range includedFiles(value, baseDir) {
+				// 读取失败的文件跳过，与 OpenSSH 一致
+				if fileHosts, err := parseConfigFile(path, baseDir, depth+1); err == nil {
+					included = append(included, fileHosts...)
+				}
+			}
+		} else if key == "host" {
 			// 创建新的主机配置
 			if currentHost != nil {
 				hosts = append(hosts, *currentHost)
 			}
 			currentHost = &SSHHost{
-				Host: value,
-				Port: "22", // 默认端口
+				Host:   value,
+				Port:   "22", // 默认端口
+				Source: configPath,
 			}
 		} else if currentHost != nil {
 			switch key {
@@ -177,6 +253,13 @@ func parseSingleConfigFile(configPath string) ([]SSHHost, error) {
 					value = filepath.Join(home, value[2:])
 				}
 				currentHost.KeyFile = value
+			case "proxyjump":
+				currentHost.ProxyJump = value
+			case "remotecommand":
+				// 保留命令原有的空白
+				currentHost.RemoteCommand = strings.TrimSpace(line[len(parts[0]):])
+			case "requesttty":
+				currentHost.RequestTTY = strings.ToLower(value)
 			}
 		}
 	}
@@ -185,6 +268,7 @@ func parseSingleConfigFile(configPath string) ([]SSHHost, error) {
 	if currentHost != nil {
 		hosts = append(hosts, *currentHost)
 	}
+	hosts = append(hosts, included...)
 
 	if err := scanner.Err(); err != nil {
 		return hosts, fmt.Errorf(i18n.T(i18n.ReadConfigFileError), err)
@@ -200,13 +284,16 @@ func parseKnownHosts() ([]SSHHost, error) {
 
 	knownHostsPath := GetKnownHostsPath()
 
-	file, err := os.Open(knownHostsPath)
+	data, err := os.ReadFile(knownHostsPath)
 	if err != nil {
+		if os.IsNotExist(err) {
+			rememberFile(knownHostsPath, "")
+		}
 		return hosts, err
 	}
-	defer file.Close()
+	rememberFile(knownHostsPath, string(data))
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -253,6 +340,15 @@ func parseKnownHosts() ([]SSHHost, error) {
 	return hosts, nil
 }
 
+// CheckEditable 检查主机配置能否修改：sshgo 只修改主配置文件（GetSSHConfigPath），
+// 定义在 Include 的文件或 ssh.config_paths 中其他文件里的主机不能修改
+func (h SSHHost) CheckEditable() error {
+	if h.Source != "" && filepath.Clean(h.Source) != filepath.Clean(GetSSHConfigPath()) {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.HostNotEditable, h.Host, h.Source))
+	}
+	return nil
+}
+
 // SaveUserToConfig 保存用户名到SSH配置文件
 func SaveUserToConfig(host, user string) error {
 	return UpdateHostDirective(host, "User", user)
@@ -281,62 +377,25 @@ func UpdateHostDirective(host, directive, value string) error {
 		}
 	}
 
-	lines := strings.Split(string(data), "\n")
-
-	// 解析为 blocks
-	type block struct {
-		header string   // 原始 Host 行
-		hosts  []string // Host 行里的所有主机模式
-		body   []string // 不包含 header 的后续行（直到下一个 Host 行 / 文件结束）
+	output := updateHostDirectiveContent(string(data), host, directive, value)
+	if output == string(data) {
+		return nil
 	}
 
-	var blocks []block
-	var current *block
-
-	flush := func() {
-		if current != nil {
-			blocks = append(blocks, *current)
-			current = nil
-		}
+	if err := WriteFileWithBackup(configPath, []byte(output)); err != nil {
+		return fmt.Errorf(i18n.T(i18n.WriteConfigFileFailed), err)
 	}
 
-	for _, raw := range lines {
-		trim := strings.TrimSpace(raw)
-		if strings.HasPrefix(strings.ToLower(trim), "host ") {
-			// 新的 block
-			flush()
-			fields := strings.Fields(trim)
-			var hostSpecs []string
-			if len(fields) > 1 {
-				hostSpecs = fields[1:]
-			}
-			current = &block{header: raw, hosts: hostSpecs}
-			continue
-		}
-		if current == nil {
-			// 文件可能前面有非 Host 行（不标准），我们直接跳过或放入匿名 block
-			if raw == "" && len(blocks) == 0 {
-				// 顶部空行忽略
-				continue
-			}
-			// 放入一个无 header 的 block（用于保留可能的注释）
-			current = &block{header: "", hosts: nil}
-		}
-		current.body = append(current.body, raw)
-	}
-	flush()
+	return nil
+}
+
+// updateHostDirectiveContent 在配置文本中更新/新增指令，返回新的配置文本
+func updateHostDirectiveContent(content, host, directive, value string) string {
+	// 解析为 blocks
+	blocks := splitConfigBlocks(content)
 
 	// 查找目标 host block
-	targetIndex := -1
-	for i, b := range blocks {
-		for _, h := range b.hosts {
-			if h == host { // 精确匹配
-				targetIndex = i
-				break
-			}
-		}
-		if targetIndex >= 0 { break }
-	}
+	targetIndex := findHostBlock(blocks, host)
 
 	directiveLower := strings.ToLower(directive)
 	updated := false
@@ -351,13 +410,18 @@ func UpdateHostDirective(host, directive, value string) error {
 				break
 			}
 		}
-		if !updated { // append at end (ensure no duplicate trailing blank lines)
-			b.body = append(b.body, fmt.Sprintf("    %s %s", directive, value))
+		if !updated { // 追加到块内最后一个非空行之后，保留块之间的空行
+			end := len(b.body)
+			for end > 0 && strings.TrimSpace(b.body[end-1]) == "" {
+				end--
+			}
+			line := fmt.Sprintf("    %s %s", directive, value)
+			b.body = append(b.body[:end:end], append([]string{line}, b.body[end:]...)...)
 		}
 		blocks[targetIndex] = b
 	} else {
 		// 创建新 block
-		newBlock := block{
+		newBlock := configBlock{
 			header: fmt.Sprintf("Host %s", host),
 			hosts:  []string{host},
 			body:   []string{fmt.Sprintf("    %s %s", directive, value), ""}, // 结尾空行
@@ -365,43 +429,38 @@ func UpdateHostDirective(host, directive, value string) error {
 		blocks = append(blocks, newBlock)
 	}
 
-	// 重新拼接
-	var out []string
-	for _, b := range blocks {
-		if b.header != "" {
-			out = append(out, b.header)
-		}
-		out = append(out, b.body...)
-	}
-
-	// 清理多余末尾空行
-	for len(out) > 0 && strings.TrimSpace(out[len(out)-1]) == "" {
-		out = out[:len(out)-1]
-	}
-	output := strings.Join(out, "\n") + "\n"
-
-	if err := os.WriteFile(configPath, []byte(output), 0600); err != nil {
-		return fmt.Errorf(i18n.T(i18n.WriteConfigFileFailed), err)
-	}
-
 	_ = directiveLower // 预留后续需要大小写归一化的扩展
-	return nil
+
+	// 重新拼接
+	return joinConfigBlocks(blocks)
 }
 
-// RemoveHostFromConfig 从SSH配置文件中删除主机配置
+// RemoveHostFromConfig 从SSH配置文件中删除主机配置，配置文件中没有该主机的配置块时返回错误
 func RemoveHostFromConfig(hostName string) error {
 	configPath := GetSSHConfigPath()
 
 	// 读取现有配置文件内容
-	content, err := os.ReadFile(configPath)
+	content, err := readFileOrEmpty(configPath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil // 文件不存在，无需操作
-		}
 		return fmt.Errorf(i18n.T(i18n.ReadConfigFileFailed), err)
 	}
 
-	lines := strings.Split(string(content), "\n")
+	output := removeHostBlock(content, hostName)
+	if output == content {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.HostBlockNotFound, hostName))
+	}
+
+	// 将修改后的内容写回文件
+	if err := WriteFileWithBackup(configPath, []byte(output)); err != nil {
+		return fmt.Errorf(i18n.T(i18n.WriteConfigFileFailed), err)
+	}
+
+	return nil
+}
+
+// removeHostBlock 从配置文本中删除指定主机的配置块
+func removeHostBlock(content, hostName string) string {
+	lines := strings.Split(content, "\n")
 	newLines := []string{}
 	inSectionToDelete := false
 
@@ -424,14 +483,7 @@ func RemoveHostFromConfig(hostName string) error {
 		}
 	}
 
-	// 将修改后的内容写回文件
-	output := strings.Join(newLines, "\n")
-	err = os.WriteFile(configPath, []byte(output), 0600)
-	if err != nil {
-		return fmt.Errorf(i18n.T(i18n.WriteConfigFileFailed), err)
-	}
-
-	return nil
+	return strings.Join(newLines, "\n")
 }
 
 // RemoveHostFromKnownHosts 从known_hosts文件中删除主机记录
@@ -448,8 +500,23 @@ func RemoveHostFromKnownHosts(hostName string) error {
 		return fmt.Errorf(i18n.T(i18n.ReadKnownHostsFailed), err)
 	}
 
-	// 将内容转换为字符串并按行分割
-	lines := strings.Split(string(content), "\n")
+	output := removeKnownHostEntries(string(content), hostName)
+	if output == string(content) {
+		return nil
+	}
+
+	// 写入更新后的内容到known_hosts文件
+	if err := WriteFileWithBackup(knownHostsPath, []byte(output)); err != nil {
+		return fmt.Errorf(i18n.T(i18n.WriteKnownHostsFailed), err)
+	}
+
+	return nil
+}
+
+// removeKnownHostEntries 从known_hosts文本中删除主机记录
+func removeKnownHostEntries(content, hostName string) string {
+	// 将内容按行分割
+	lines := strings.Split(content, "\n")
 
 	// 查找并删除主机记录
 	newLines := []string{}
@@ -495,11 +562,26 @@ func RemoveHostFromKnownHosts(hostName string) error {
 		}
 	}
 
-	// 写入更新后的内容到known_hosts文件
-	err = os.WriteFile(knownHostsPath, []byte(strings.Join(newLines, "\n")), 0600)
+	return strings.Join(newLines, "\n")
+}
+
+// PreviewRemoveHost 预览删除主机时 config 与 known_hosts 的变化（统一 diff 格式）
+func PreviewRemoveHost(hostName string) (string, error) {
+	var s strings.Builder
+
+	configPath := GetSSHConfigPath()
+	content, err := readFileOrEmpty(configPath)
 	if err != nil {
-		return fmt.Errorf(i18n.T(i18n.WriteKnownHostsFailed), err)
+		return "", fmt.Errorf(i18n.T(i18n.ReadConfigFileFailed), err)
 	}
+	s.WriteString(UnifiedDiff(configPath, configPath, content, removeHostBlock(content, hostName)))
 
-	return nil
+	knownHostsPath := GetKnownHostsPath()
+	content, err = readFileOrEmpty(knownHostsPath)
+	if err != nil {
+		return "", fmt.Errorf(i18n.T(i18n.ReadKnownHostsFailed), err)
+	}
+	s.WriteString(UnifiedDiff(knownHostsPath, knownHostsPath, content, removeKnownHostEntries(content, hostName)))
+
+	return s.String(), nil
 }
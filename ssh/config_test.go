@@ -0,0 +1,56 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Include 的文件中的主机记录来源文件，不能修改；删除时找不到配置块返回错误而不是静默成功
+func TestIncludedHostNotEditable(t *testing.T) {
+	config := "Include conf.d/*\n\nHost web-1\n    User deploy\n"
+	setupTestHome(t, config, "")
+	configPath := GetSSHConfigPath()
+	included := filepath.Join(filepath.Dir(configPath), "conf.d", "work")
+	if err := os.MkdirAll(filepath.Dir(included), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(included, []byte("Host db-1\n    Port 2222\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	hosts, err := parseSingleConfigFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := make(map[string]SSHHost)
+	for _, h := range hosts {
+		sources[h.Host] = h
+	}
+	if got := sources["web-1"].Source; got != configPath {
+		t.Errorf("Source of web-1 = %q, want %q", got, configPath)
+	}
+	if got := sources["db-1"].Source; got != included {
+		t.Errorf("Source of db-1 = %q, want %q", got, included)
+	}
+
+	if err := sources["web-1"].CheckEditable(); err != nil {
+		t.Errorf("CheckEditable(web-1) error = %v", err)
+	}
+	if err := sources["db-1"].CheckEditable(); err == nil {
+		t.Error("CheckEditable(db-1) should fail for a host in an included file")
+	}
+	if err := (SSHHost{Host: "10.0.0.1"}).CheckEditable(); err != nil {
+		t.Errorf("CheckEditable() of a known_hosts host error = %v", err)
+	}
+
+	if err := RemoveHostFromConfig("db-1"); err == nil {
+		t.Error("RemoveHostFromConfig(db-1) should fail when the main config has no block")
+	}
+	if got := readTestFile(t, configPath); got != config {
+		t.Errorf("config =\n%s\nwant unchanged:\n%s", got, config)
+	}
+	if got := readTestFile(t, included); got != "Host db-1\n    Port 2222\n" {
+		t.Errorf("included file changed:\n%s", got)
+	}
+}
@@ -6,15 +6,14 @@ import (
 	"os/exec"
 	"sshgo/i18n"
 	"strconv"
+	"strings"
 )
 
 // validateSSHCommand 验证SSH命令和参数的有效性
+// args 为 ssh 的参数列表：选项、主机名，以及主机名之后可选的远程命令
 func validateSSHCommand(args []string) error {
 	// 检查SSH命令是否存在
-	sshPath, err := exec.LookPath("ssh")
-	// sshPath变量已被声明但未使用，这里添加一个简单的使用
-	_ = sshPath // 忽略未使用的变量警告
-	if err != nil {
+	if _, err := exec.LookPath("ssh"); err != nil {
 		return fmt.Errorf("SSH command not found: %w", err)
 	}
 
@@ -23,59 +22,102 @@ func validateSSHCommand(args []string) error {
 		return fmt.Errorf("no arguments provided")
 	}
 
+	// 逐个检查选项，第一个非选项参数为主机名，之后为远程命令
+	hostName := ""
+	var command []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if len(arg) < 2 || arg[0] != '-' {
+			hostName = arg
+			command = args[i+1:]
+			break
+		}
+
+		flag, attached, err := parseFlag(arg)
+		if err != nil {
+			return err
+		}
+		if flag == 0 {
+			continue
+		}
+		var value string
+		if attached {
+			value = arg[strings.IndexByte(arg, flag)+1:]
+		} else if i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		if err := validateOptionValue(flag, value); err != nil {
+			return err
+		}
+	}
+
 	// 检查主机名是否为空
-	hostName := args[len(args)-1] // 主机名通常是最后一个参数
 	if hostName == "" {
 		return fmt.Errorf("host name is required")
 	}
 
-	// 检查端口号是否有效（如果提供了的话）
-	for i, arg := range args {
-		if arg == "-p" && i+1 < len(args) {
-			port := args[i+1]
-			if port == "" {
-				return fmt.Errorf("port number is required when -p flag is used")
-			}
-			if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
-				return fmt.Errorf("invalid port number: %s", port)
-			}
+	// 远程命令中不能包含空字符
+	for _, arg := range command {
+		if strings.ContainsRune(arg, 0) {
+			return fmt.Errorf("invalid remote command argument: %q", arg)
 		}
 	}
 
-	// 检查密钥文件是否存在（如果提供了的话）
-	for i, arg := range args {
-		if arg == "-i" && i+1 < len(args) {
-			keyFile := args[i+1]
-			if keyFile == "" {
-				return fmt.Errorf("key file path is required when -i flag is used")
-			}
-			// 检查文件是否存在
-			if _, err := os.Stat(keyFile); os.IsNotExist(err) {
-				return fmt.Errorf("key file does not exist: %s", keyFile)
-			}
+	return nil
+}
+
+// validateOptionValue 检查需要参数的选项的值
+func validateOptionValue(flag byte, value string) error {
+	switch flag {
+	case 'p':
+		// 检查端口号是否有效
+		if value == "" {
+			return fmt.Errorf("port number is required when -p flag is used")
+		}
+		if p, err := strconv.Atoi(value); err != nil || p < 1 || p > 65535 {
+			return fmt.Errorf("invalid port number: %s", value)
+		}
+	case 'i':
+		// 检查密钥文件是否存在
+		if value == "" {
+			return fmt.Errorf("key file path is required when -i flag is used")
+		}
+		if _, err := os.Stat(value); os.IsNotExist(err) {
+			return fmt.Errorf("key file does not exist: %s", value)
+		}
+	case 'o':
+		// 选项格式为 Key=Value 或 "Key Value"
+		if !strings.ContainsAny(value, "= \t") {
+			return fmt.Errorf("invalid -o option, expected Key=Value: %s", value)
+		}
+	default:
+		if value == "" {
+			return fmt.Errorf("option -%c requires an argument", flag)
 		}
 	}
+	return nil
+}
 
+// ValidatePort 校验端口号是否在 1-65535 之间
+func ValidatePort(port string) error {
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.InvalidPortNumber, port))
+	}
 	return nil
 }
 
-// NeedsUsername 检查是否需要用户名
+// NeedsUsername 检查是否需要用户名：主机未配置用户且无法获取本地用户名时才需要询问
 func NeedsUsername(host SSHHost) bool {
-	return host.User == ""
+	return host.EffectiveUser() == "" && LocalUsername() == ""
 }
 
-// ConnectToHost 连接到指定主机
-func ConnectToHost(host SSHHost) error {
-	// 如果没有用户名，返回错误（用户名应由 UI 层获取）
-	if host.User == "" {
-		return fmt.Errorf("%s", i18n.T(i18n.UsernameNotSet))
-	}
-
-	// 构建SSH命令
+// hostArgs 根据主机配置构建 ssh 的用户、端口和密钥参数（不含目标地址）
+func hostArgs(host SSHHost) []string {
 	args := []string{}
 
-	if host.User != "" {
-		args = append(args, "-l", host.User)
+	if user := host.EffectiveUser(); user != "" {
+		args = append(args, "-l", user)
 	}
 
 	if host.Port != "22" && host.Port != "" {
@@ -86,37 +128,27 @@ func ConnectToHost(host SSHHost) error {
 		args = append(args, "-i", host.KeyFile)
 	}
 
-	hostName := host.HostName
-	if hostName == "" {
-		hostName = host.Host
+	if host.ProxyJump != "" && !strings.EqualFold(host.ProxyJump, "none") {
+		args = append(args, "-J", host.ProxyJump)
 	}
 
-	args = append(args, hostName)
-
-	// 预校验SSH命令和参数
-	if err := validateSSHCommand(args); err != nil {
-		return fmt.Errorf("%s", i18n.TWithArgs(i18n.InvalidSSHCommand, err))
-	}
-
-	// 执行SSH命令
-	cmd := exec.Command("ssh", args...)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	fmt.Printf("%s", i18n.TWithArgs(i18n.ConnectingTo, host.User, host.Host)+"\n")
-	return cmd.Run()
+	return args
 }
 
-// ConnectToHostWithUser 使用指定用户名连接到主机
-func ConnectToHostWithUser(host SSHHost, username string) error {
-	host.User = username
-	
-	// 保存用户名到配置文件
-	err := SaveUserToConfig(host.Host, username)
-	if err != nil {
-		fmt.Printf(i18n.T(i18n.Warning)+"\n", err)
+// CommandArgs 构建连接主机的完整 ssh 命令行（第一个元素为 ssh），并预校验参数
+// 交互式连接才会带上 RemoteCommand / RequestTTY；命令行指定了远程命令时以其为准
+func CommandArgs(host SSHHost) ([]string, error) {
+	args := hostArgs(host)
+	if len(host.Command) == 0 {
+		args = append(args, remoteCommandArgs(host)...)
+	} else {
+		args = append(args, noRemoteCommandArgs...)
 	}
-
-	return ConnectToHost(host)
+	args = append(args, host.Options...)
+	args = append(args, host.Target())
+	args = append(args, host.Command...)
+	if err := validateSSHCommand(args); err != nil {
+		return nil, fmt.Errorf("%s", i18n.TWithArgs(i18n.InvalidSSHCommand, err))
+	}
+	return append([]string{"ssh"}, args...), nil
 }
@@ -0,0 +1,146 @@
+package ssh
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diff 上下文行数
+const diffContext = 3
+
+// diffOp 单行差异操作
+type diffOp struct {
+	kind byte // ' ' 相同, '-' 删除, '+' 新增
+	line string
+}
+
+// UnifiedDiff 生成两段文本之间的统一格式 diff，无差异时返回空字符串
+func UnifiedDiff(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	a := splitLines(oldText)
+	b := splitLines(newText)
+	ops := diffLines(a, b)
+
+	var s strings.Builder
+	fmt.Fprintf(&s, "--- %s\n+++ %s\n", oldName, newName)
+
+	// 按上下文将操作分组成 hunk
+	i := 0
+	for i < len(ops) {
+		// 找到下一处变化
+		for i < len(ops) && ops[i].kind == ' ' {
+			i++
+		}
+		if i >= len(ops) {
+			break
+		}
+
+		start := max(i-diffContext, 0)
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			// 连续相同行超过 2*context 时结束当前 hunk
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run >= len(ops) || run-end > 2*diffContext {
+				end = min(end+diffContext, len(ops))
+				break
+			}
+			end = run
+		}
+
+		// 计算 hunk 头部的行号
+		oldStart, newStart := 1, 1
+		for _, op := range ops[:start] {
+			if op.kind != '+' {
+				oldStart++
+			}
+			if op.kind != '-' {
+				newStart++
+			}
+		}
+		oldCount, newCount := 0, 0
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+
+		fmt.Fprintf(&s, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, op := range ops[start:end] {
+			s.WriteByte(op.kind)
+			s.WriteString(op.line)
+			s.WriteByte('\n')
+		}
+		i = end
+	}
+
+	return s.String()
+}
+
+// splitLines 按行切分文本，忽略末尾换行
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines 基于最长公共子序列计算逐行差异
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
@@ -0,0 +1,24 @@
+package ssh
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	oldText := "Host a\n    User root\n\nHost b\n    User deploy\n"
+	newText := "Host a\n    User root\n"
+
+	got := UnifiedDiff("config", "config", oldText, newText)
+	want := "--- config\n+++ config\n" +
+		"@@ -1,5 +1,2 @@\n" +
+		" Host a\n" +
+		"     User root\n" +
+		"-\n" +
+		"-Host b\n" +
+		"-    User deploy\n"
+	if got != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if d := UnifiedDiff("a", "b", oldText, oldText); d != "" {
+		t.Errorf("UnifiedDiff() of identical text = %q, want empty", d)
+	}
+}
@@ -0,0 +1,62 @@
+package ssh
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// effectiveConfigTimeout ssh -G 的超时（ProxyCommand 等不会执行，正常情况下立即返回）
+const effectiveConfigTimeout = 5 * time.Second
+
+// ConfigOption ssh -G 输出的一项配置
+type ConfigOption struct {
+	Key   string
+	Value string
+}
+
+// EffectiveConfig 通过 ssh -G 获取连接主机时实际生效的全部配置，参数与连接时相同
+func EffectiveConfig(host SSHHost) ([]ConfigOption, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), effectiveConfigTimeout)
+	defer cancel()
+
+	args := append([]string{"-G"}, hostArgs(host)...)
+	args = append(args, host.Target())
+	out, err := exec.CommandContext(ctx, "ssh", args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseEffectiveConfig(string(out)), nil
+}
+
+// parseEffectiveConfig 解析 ssh -G 的输出，每行为小写的选项名和取值
+func parseEffectiveConfig(output string) []ConfigOption {
+	var options []ConfigOption
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		options = append(options, ConfigOption{Key: key, Value: strings.TrimSpace(value)})
+	}
+	return options
+}
+
+// IdentityFile 返回生效配置中第一个存在的 identityfile（展开 ~），都不存在时返回空
+func IdentityFile(options []ConfigOption) string {
+	for _, o := range options {
+		if o.Key != "identityfile" {
+			continue
+		}
+		path := expandHome(o.Value)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
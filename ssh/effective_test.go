@@ -0,0 +1,19 @@
+package ssh
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseEffectiveConfig(t *testing.T) {
+	got := parseEffectiveConfig("host web-1\nuser deploy\n\nidentityfile ~/.ssh/id_ed25519\nsendenv LANG\n")
+	want := []ConfigOption{
+		{"host", "web-1"},
+		{"user", "deploy"},
+		{"identityfile", "~/.ssh/id_ed25519"},
+		{"sendenv", "LANG"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseEffectiveConfig() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,47 @@
+package ssh
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+)
+
+// KeyInfo 密钥文件的信息，公钥取自同名的 .pub 文件
+type KeyInfo struct {
+	Path        string
+	Exists      bool   // 私钥文件是否存在
+	Type        string // 公钥类型，如 ssh-ed25519
+	Fingerprint string // 与 ssh-keygen -l 相同的 SHA256 指纹
+	Comment     string
+}
+
+// ReadKeyInfo 读取密钥文件的信息；没有 .pub 文件时只返回是否存在
+func ReadKeyInfo(path string) KeyInfo {
+	info := KeyInfo{Path: path}
+	if _, err := os.Stat(path); err == nil {
+		info.Exists = true
+	}
+	data, err := os.ReadFile(path + ".pub")
+	if err != nil {
+		return info
+	}
+	info.Type, info.Fingerprint, info.Comment, _ = parsePublicKey(string(data))
+	return info
+}
+
+// parsePublicKey 解析 authorized_keys 格式的公钥行，返回类型、SHA256 指纹和注释
+func parsePublicKey(line string) (keyType, fingerprint, comment string, err error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return "", "", "", errors.New("invalid public key")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", "", "", err
+	}
+	sum := sha256.Sum256(blob)
+	fingerprint = "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+	return fields[0], fingerprint, strings.Join(fields[2:], " "), nil
+}
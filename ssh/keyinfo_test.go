@@ -0,0 +1,36 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadKeyInfo(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "id_ed25519")
+	pub := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKjfyXQTdhpUK9+II7jHaCYVBJIIWIhwT0F3hnHRdn7A test@sshgo\n"
+	if err := os.WriteFile(path, []byte("private"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path+".pub", []byte(pub), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ReadKeyInfo(path)
+	want := KeyInfo{
+		Path:        path,
+		Exists:      true,
+		Type:        "ssh-ed25519",
+		Fingerprint: "SHA256:kzlX35/4F42EjSVBBXLVbPr74FPP6d8nD8Czc3vtvSc",
+		Comment:     "test@sshgo",
+	}
+	if got != want {
+		t.Errorf("ReadKeyInfo() = %+v, want %+v", got, want)
+	}
+
+	missing := filepath.Join(dir, "missing")
+	if got := ReadKeyInfo(missing); got != (KeyInfo{Path: missing}) {
+		t.Errorf("ReadKeyInfo(missing) = %+v, want only the path", got)
+	}
+}
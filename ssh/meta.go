@@ -0,0 +1,127 @@
+package ssh
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sshgo/i18n"
+)
+
+// metaPrefix sshgo 元数据注释前缀，OpenSSH 会将其视为普通注释忽略
+// 例如: #sshgo: tags=prod,db group=eu
+const metaPrefix = "#sshgo:"
+
+// HostMeta 主机的 sshgo 元数据（标签与分组）
+type HostMeta struct {
+	Tags  []string
+	Group string
+}
+
+// IsEmpty 元数据是否为空
+func (m HostMeta) IsEmpty() bool {
+	return len(m.Tags) == 0 && m.Group == ""
+}
+
+// String 格式化为注释中使用的 key=value 形式（不含前缀）
+func (m HostMeta) String() string {
+	var parts []string
+	if len(m.Tags) > 0 {
+		parts = append(parts, "tags="+strings.Join(m.Tags, ","))
+	}
+	if m.Group != "" {
+		parts = append(parts, "group="+m.Group)
+	}
+	return strings.Join(parts, " ")
+}
+
+// isMetaLine 判断一行是否为 sshgo 元数据注释
+func isMetaLine(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), metaPrefix)
+}
+
+// ParseHostMeta 解析 key=value 形式的元数据（可带或不带 #sshgo: 前缀）
+func ParseHostMeta(text string) (HostMeta, error) {
+	var meta HostMeta
+	text = strings.TrimPrefix(strings.TrimSpace(text), metaPrefix)
+
+	for _, field := range strings.Fields(text) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return meta, fmt.Errorf("%s", i18n.TWithArgs(i18n.InvalidHostMeta, field))
+		}
+		switch strings.ToLower(key) {
+		case "tags", "tag":
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					meta.Tags = append(meta.Tags, tag)
+				}
+			}
+		case "group":
+			meta.Group = value
+		default:
+			return meta, fmt.Errorf("%s", i18n.TWithArgs(i18n.InvalidHostMeta, field))
+		}
+	}
+
+	// 标签去重并排序，保证写回结果稳定
+	if len(meta.Tags) > 0 {
+		seen := make(map[string]bool)
+		var tags []string
+		for _, tag := range meta.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+		sort.Strings(tags)
+		meta.Tags = tags
+	}
+	return meta, nil
+}
+
+// SetHostMeta 更新主机配置块中的 #sshgo: 注释；元数据为空时删除该注释
+func SetHostMeta(host string, meta HostMeta) error {
+	configPath := GetSSHConfigPath()
+	content, err := readFileOrEmpty(configPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T(i18n.ReadConfigFileFailed), err)
+	}
+
+	output, err := setHostMetaContent(content, host, meta)
+	if err != nil {
+		return err
+	}
+	if output == content {
+		return nil
+	}
+
+	if err := WriteFileWithBackup(configPath, []byte(output)); err != nil {
+		return fmt.Errorf(i18n.T(i18n.WriteConfigFileFailed), err)
+	}
+	return nil
+}
+
+// setHostMetaContent 在配置文本中更新主机的元数据注释，注释放在 Host 行之后
+func setHostMetaContent(content, host string, meta HostMeta) (string, error) {
+	blocks := splitConfigBlocks(content)
+	index := findHostBlock(blocks, host)
+	if index < 0 {
+		return "", fmt.Errorf("%s", i18n.TWithArgs(i18n.HostBlockNotFound, host))
+	}
+
+	b := blocks[index]
+	var body []string
+	for _, line := range b.body {
+		if !isMetaLine(line) {
+			body = append(body, line)
+		}
+	}
+	if !meta.IsEmpty() {
+		body = append([]string{"    " + metaPrefix + " " + meta.String()}, body...)
+	}
+	b.body = body
+	blocks[index] = b
+
+	return joinConfigBlocks(blocks), nil
+}
@@ -0,0 +1,73 @@
+package ssh
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHostMeta(t *testing.T) {
+	cases := []struct {
+		in    string
+		tags  []string
+		group string
+		err   bool
+	}{
+		{"#sshgo: tags=prod,db group=eu", []string{"db", "prod"}, "eu", false},
+		{"tags=web,web, group=us", []string{"web"}, "us", false},
+		{"group=eu", nil, "eu", false},
+		{"", nil, "", false},
+		{"tags", nil, "", true},
+		{"color=red", nil, "", true},
+	}
+
+	for _, c := range cases {
+		got, err := ParseHostMeta(c.in)
+		if (err != nil) != c.err {
+			t.Errorf("ParseHostMeta(%q) error = %v, want error %v", c.in, err, c.err)
+			continue
+		}
+		if c.err {
+			continue
+		}
+		if !reflect.DeepEqual(got.Tags, c.tags) || got.Group != c.group {
+			t.Errorf("ParseHostMeta(%q) = %+v, want tags=%v group=%q", c.in, got, c.tags, c.group)
+		}
+	}
+}
+
+func TestSetHostMetaContent(t *testing.T) {
+	content := "Host web-1\n    #sshgo: tags=old\n    User deploy\n"
+
+	got, err := setHostMetaContent(content, "web-1", HostMeta{Tags: []string{"prod"}, Group: "eu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Host web-1\n    #sshgo: tags=prod group=eu\n    User deploy\n"
+	if got != want {
+		t.Errorf("setHostMetaContent() =\n%s\nwant:\n%s", got, want)
+	}
+
+	got, err = setHostMetaContent(want, "web-1", HostMeta{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Host web-1\n    User deploy\n"; got != want {
+		t.Errorf("setHostMetaContent() with empty meta =\n%s\nwant:\n%s", got, want)
+	}
+
+	hosts := parseTestConfig(t, "Host web-1\n    #sshgo: tags=prod group=eu\n    User deploy\n")
+	if len(hosts) != 1 || hosts[0].Group != "eu" || !reflect.DeepEqual(hosts[0].Tags, []string{"prod"}) {
+		t.Errorf("parsed hosts = %+v, want tags=[prod] group=eu", hosts)
+	}
+}
+
+// parseTestConfig 将配置文本写入临时文件并解析
+func parseTestConfig(t *testing.T, content string) []SSHHost {
+	t.Helper()
+	setupTestHome(t, content, "")
+	hosts, err := parseSingleConfigFile(GetSSHConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hosts
+}
@@ -0,0 +1,116 @@
+package ssh
+
+import (
+	"fmt"
+	"strings"
+
+	"sshgo/i18n"
+)
+
+// ssh 的单字母选项：需要参数的与不需要参数的（与 OpenSSH ssh(1) 一致）
+const (
+	sshArgFlags  = "BbcDEeFIiJLlmOoPpQRSWw"
+	sshBoolFlags = "46AaCfGgKkMNnqsTtVvXxYy"
+)
+
+// parseFlag 解析一个以 - 开头的选项（可能是 -At 这样的组合），
+// 返回其中需要参数的选项字母（0 表示没有），以及参数是否已附在选项之后（如 -p22）
+func parseFlag(arg string) (argFlag byte, attached bool, err error) {
+	for i := 1; i < len(arg); i++ {
+		c := arg[i]
+		switch {
+		case strings.IndexByte(sshArgFlags, c) >= 0:
+			return c, i+1 < len(arg), nil
+		case strings.IndexByte(sshBoolFlags, c) >= 0:
+			continue
+		default:
+			return 0, false, fmt.Errorf("unknown ssh option: -%c", c)
+		}
+	}
+	return 0, false, nil
+}
+
+// ParseCommandLine 解析 "sshgo [ssh 选项] 主机 [ssh 选项] [命令 [参数...]]"
+// 返回主机参数、ssh 选项以及主机之后的远程命令；选项可以出现在主机前后，
+// 主机之后第一个非选项参数（或 -- 之后的参数）开始为远程命令
+func ParseCommandLine(args []string) (hostArg string, options, command []string, err error) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest := args[i+1:]
+			if hostArg == "" {
+				if len(rest) == 0 {
+					break
+				}
+				hostArg, rest = rest[0], rest[1:]
+			}
+			command = rest
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			if hostArg != "" {
+				command = args[i:]
+				break
+			}
+			hostArg = arg
+			continue
+		}
+
+		flag, attached, err := parseFlag(arg)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		options = append(options, arg)
+		if flag != 0 && !attached {
+			if i+1 >= len(args) {
+				return "", nil, nil, fmt.Errorf("%s", i18n.TWithArgs(i18n.OptionRequiresArgument, "-"+string(flag)))
+			}
+			i++
+			options = append(options, args[i])
+		}
+	}
+
+	if hostArg == "" {
+		return "", nil, nil, fmt.Errorf("%s", i18n.T(i18n.HostArgumentRequired))
+	}
+	return hostArg, options, command, nil
+}
+
+// ApplyOptions 将命令行中的 -l / -p / -J 合并到主机配置中，其余选项原样保留在 Options 中
+// ssh 对这几个选项只采用第一次出现的值或不允许重复，合并后可避免与配置生成的参数冲突
+func (h SSHHost) ApplyOptions(options []string) SSHHost {
+	h.Options = nil
+	for i := 0; i < len(options); i++ {
+		arg := options[i]
+		flag, attached, _ := parseFlag(arg)
+		if flag == 0 {
+			h.Options = append(h.Options, arg)
+			continue
+		}
+
+		prefix := arg[:strings.IndexByte(arg, flag)]
+		var value string
+		if attached {
+			value = arg[len(prefix)+1:]
+		} else if i+1 < len(options) {
+			i++
+			value = options[i]
+		}
+
+		switch flag {
+		case 'l':
+			h.User = value
+		case 'p':
+			h.Port = value
+		case 'J':
+			h.ProxyJump = value
+		default:
+			h.Options = append(h.Options, "-"+string(flag), value)
+		}
+		// 保留组合选项中位于参数型选项之前的布尔选项，如 -Ap22 中的 -A
+		if prefix != "-" {
+			h.Options = append(h.Options, prefix)
+		}
+	}
+	return h
+}
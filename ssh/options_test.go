@@ -0,0 +1,80 @@
+package ssh
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestParseCommandLine(t *testing.T) {
+	tests := []struct {
+		args    []string
+		host    string
+		options []string
+		command []string
+	}{
+		{[]string{"web-1"}, "web-1", nil, nil},
+		{[]string{"web-1", "-A", "-L", "8080:localhost:80", "uptime"}, "web-1", []string{"-A", "-L", "8080:localhost:80"}, []string{"uptime"}},
+		{[]string{"-p2222", "deploy@web-1", "ls", "-la", "/tmp"}, "deploy@web-1", []string{"-p2222"}, []string{"ls", "-la", "/tmp"}},
+		{[]string{"-At", "web-1", "--", "-weird"}, "web-1", []string{"-At"}, []string{"-weird"}},
+		{[]string{"-o", "ServerAliveInterval=30", "web-1"}, "web-1", []string{"-o", "ServerAliveInterval=30"}, nil},
+		// sshgo -- <alias>：与子命令同名的别名
+		{[]string{"--", "list", "uptime"}, "list", nil, []string{"uptime"}},
+	}
+	for _, tt := range tests {
+		host, options, command, err := ParseCommandLine(tt.args)
+		if err != nil {
+			t.Errorf("ParseCommandLine(%q) error: %v", tt.args, err)
+			continue
+		}
+		if host != tt.host || !reflect.DeepEqual(options, tt.options) || !reflect.DeepEqual(command, tt.command) {
+			t.Errorf("ParseCommandLine(%q) = %q, %q, %q; want %q, %q, %q", tt.args, host, options, command, tt.host, tt.options, tt.command)
+		}
+	}
+
+	for _, args := range [][]string{{"-A"}, {"web-1", "-L"}, {"-Z", "web-1"}} {
+		if _, _, _, err := ParseCommandLine(args); err == nil {
+			t.Errorf("ParseCommandLine(%q) expected error", args)
+		}
+	}
+}
+
+func TestApplyOptions(t *testing.T) {
+	host := SSHHost{Host: "web-1", User: "root", Port: "22"}
+	got := host.ApplyOptions([]string{"-A", "-l", "deploy", "-Cp2222", "-J", "bastion", "-L", "8080:localhost:80"})
+	if got.User != "deploy" || got.Port != "2222" || got.ProxyJump != "bastion" {
+		t.Errorf("ApplyOptions() host = %+v", got)
+	}
+	want := []string{"-A", "-C", "-L", "8080:localhost:80"}
+	if !reflect.DeepEqual(got.Options, want) {
+		t.Errorf("ApplyOptions() options = %q, want %q", got.Options, want)
+	}
+}
+
+func TestValidateSSHCommand(t *testing.T) {
+	if _, err := exec.LookPath("ssh"); err != nil {
+		t.Skip("ssh not installed")
+	}
+	valid := [][]string{
+		{"-p", "2222", "web-1"},
+		{"-A", "-o", "ServerAliveInterval=30", "web-1", "uptime"},
+		{"-L8080:localhost:80", "web-1", "ls", "-la"},
+	}
+	for _, args := range valid {
+		if err := validateSSHCommand(args); err != nil {
+			t.Errorf("validateSSHCommand(%q) error: %v", args, err)
+		}
+	}
+	invalid := [][]string{
+		{"-p", "99999", "web-1"},
+		{"-Z", "web-1"},
+		{"-o", "novalue", "web-1"},
+		{"-A"},
+		{"-i", "/nonexistent/key", "web-1"},
+	}
+	for _, args := range invalid {
+		if err := validateSSHCommand(args); err == nil {
+			t.Errorf("validateSSHCommand(%q) expected error", args)
+		}
+	}
+}
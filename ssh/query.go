@@ -0,0 +1,152 @@
+package ssh
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// queryFields 搜索中支持的字段条件；exact 为 true 的字段要求完全一致，其余为包含匹配
+var queryFields = map[string]struct {
+	exact  bool
+	values func(SSHHost) []string
+}{
+	"host":  {false, func(h SSHHost) []string { return append(strings.Fields(h.Host), h.HostName) }},
+	"user":  {true, func(h SSHHost) []string { return []string{h.User} }},
+	"port":  {true, func(h SSHHost) []string { return []string{hostPort(h)} }},
+	"tag":   {true, func(h SSHHost) []string { return h.Tags }},
+	"group": {true, func(h SSHHost) []string { return []string{h.Group} }},
+	"key":   {false, func(h SSHHost) []string { return []string{h.KeyFile} }},
+	"via":   {false, func(h SSHHost) []string { return []string{h.ProxyJump} }},
+}
+
+// hostPort 返回主机端口，未配置时为 22
+func hostPort(h SSHHost) string {
+	if h.Port == "" {
+		return "22"
+	}
+	return h.Port
+}
+
+// QueryFilter 字段条件，如 user:deploy；以 - 开头表示排除
+type QueryFilter struct {
+	Field  string
+	Value  string
+	Negate bool
+}
+
+// Query 主机搜索条件：全部字段条件都满足，且自由文本模糊匹配别名、HostName、分组或标签
+type Query struct {
+	Filters []QueryFilter
+	Text    string
+}
+
+// ParseQuery 解析搜索条件，如 "user:deploy port:2222 tag:prod -via:bastion web"
+// 未知字段（如 IPv6 地址中的冒号）按自由文本处理
+func ParseQuery(s string) Query {
+	var q Query
+	var text []string
+	for _, token := range strings.Fields(s) {
+		negate := strings.HasPrefix(token, "-")
+		field, value, ok := strings.Cut(strings.TrimPrefix(token, "-"), ":")
+		field = strings.ToLower(field)
+		if _, known := queryFields[field]; !ok || !known {
+			text = append(text, token)
+			continue
+		}
+		q.Filters = append(q.Filters, QueryFilter{Field: field, Value: value, Negate: negate})
+	}
+	q.Text = strings.Join(text, " ")
+	return q
+}
+
+// matchValue 匹配单个取值：支持 * 和 ? 通配符，不区分大小写；条件值为空时只要求取值非空
+func matchValue(value, candidate string, exact bool) bool {
+	switch {
+	case candidate == "":
+		return false
+	case value == "":
+		return true
+	case strings.ContainsAny(value, "*?"):
+		return matchPattern(value, candidate) || matchPattern(value, filepath.Base(candidate))
+	case exact:
+		return strings.EqualFold(value, candidate)
+	default:
+		return strings.Contains(strings.ToLower(candidate), strings.ToLower(value))
+	}
+}
+
+// Matches 主机是否满足该字段条件
+func (f QueryFilter) Matches(h SSHHost) bool {
+	field := queryFields[f.Field]
+	matched := false
+	for _, v := range field.values(h) {
+		if matchValue(f.Value, v, field.exact) {
+			matched = true
+			break
+		}
+	}
+	return matched != f.Negate
+}
+
+// MatchFields 主机是否满足全部字段条件
+func (q Query) MatchFields(h SSHHost) bool {
+	for _, f := range q.Filters {
+		if !f.Matches(h) {
+			return false
+		}
+	}
+	return true
+}
+
+// QueryMatch 搜索结果：Index 为在输入中的位置，MatchedIndexes 为自由文本匹配到的字符位置（用于高亮）
+type QueryMatch struct {
+	Index          int
+	MatchedIndexes []int
+}
+
+// Search 在 targets 中搜索，hosts[i] 为 targets[i] 对应的主机（nil 表示不是主机，有字段条件时不会匹配）
+// 有自由文本时按模糊匹配得分排序，否则保持原有顺序
+func (q Query) Search(targets []string, hosts []*SSHHost) []QueryMatch {
+	var candidates []string
+	var indexes []int
+	for i, target := range targets {
+		if len(q.Filters) > 0 && (hosts[i] == nil || !q.MatchFields(*hosts[i])) {
+			continue
+		}
+		candidates = append(candidates, target)
+		indexes = append(indexes, i)
+	}
+
+	if q.Text == "" {
+		matches := make([]QueryMatch, len(indexes))
+		for i, index := range indexes {
+			matches[i] = QueryMatch{Index: index}
+		}
+		return matches
+	}
+
+	found := fuzzy.Find(q.Text, candidates)
+	matches := make([]QueryMatch, len(found))
+	for i, m := range found {
+		matches[i] = QueryMatch{Index: indexes[m.Index], MatchedIndexes: m.MatchedIndexes}
+	}
+	return matches
+}
+
+// SearchText 自由文本匹配的内容：别名、HostName、分组与标签
+func SearchText(h SSHHost) string {
+	return strings.Join(append([]string{h.Host, h.HostName, h.Group}, h.Tags...), " ")
+}
+
+// SearchHosts 按搜索条件过滤主机
+func SearchHosts(hosts []SSHHost, q Query) []QueryMatch {
+	targets := make([]string, len(hosts))
+	ptrs := make([]*SSHHost, len(hosts))
+	for i := range hosts {
+		targets[i] = SearchText(hosts[i])
+		ptrs[i] = &hosts[i]
+	}
+	return q.Search(targets, ptrs)
+}
@@ -0,0 +1,63 @@
+package ssh
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	got := ParseQuery("user:deploy Port:2222 -via:bastion web fe80::1 foo:bar")
+	want := Query{
+		Filters: []QueryFilter{
+			{Field: "user", Value: "deploy"},
+			{Field: "port", Value: "2222"},
+			{Field: "via", Value: "bastion", Negate: true},
+		},
+		Text: "web fe80::1 foo:bar",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseQuery() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSearchHosts(t *testing.T) {
+	hosts := []SSHHost{
+		{Host: "web-1", HostName: "10.0.0.1", User: "deploy", Port: "2222", Tags: []string{"prod", "web"}, KeyFile: "/home/u/.ssh/id_ed25519", ProxyJump: "bastion"},
+		{Host: "web-2", HostName: "10.0.0.2", User: "deploy", Port: "22", Tags: []string{"staging"}},
+		{Host: "db-1", HostName: "10.0.1.1", User: "postgres", Tags: []string{"prod"}, Group: "data", KeyFile: "/home/u/.ssh/id_rsa"},
+	}
+
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"user:deploy", []string{"web-1", "web-2"}},
+		{"port:22", []string{"web-2", "db-1"}},
+		{"tag:prod", []string{"web-1", "db-1"}},
+		{"tag:prod -tag:web", []string{"db-1"}},
+		{"key:id_ed25519", []string{"web-1"}},
+		{"key:id_*", []string{"web-1", "db-1"}},
+		{"via:bastion", []string{"web-1"}},
+		{"-via:", []string{"web-2", "db-1"}},
+		{"group:DATA", []string{"db-1"}},
+		{"host:10.0.0", []string{"web-1", "web-2"}},
+		{"user:deploy web2", []string{"web-2"}},
+		{"tag:prod db-", []string{"db-1"}},
+		{"user:nobody", nil},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, m := range SearchHosts(hosts, ParseQuery(c.query)) {
+			got = append(got, hosts[m.Index].Host)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SearchHosts(%q) = %v, want %v", c.query, got, c.want)
+		}
+	}
+
+	// 自由文本的匹配位置用于高亮
+	matches := SearchHosts(hosts, ParseQuery("tag:prod db-"))
+	if len(matches) != 1 || !reflect.DeepEqual(matches[0].MatchedIndexes, []int{0, 1, 2}) {
+		t.Errorf("SearchHosts() matched indexes = %+v, want [0 1 2]", matches)
+	}
+}
@@ -0,0 +1,70 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+
+	"sshgo/i18n"
+)
+
+// remoteCommandOptions 非交互执行远程命令时使用的 ssh 选项：
+// 禁止密码提示（否则会卡住并发执行），不分配终端，并限制连接超时
+var remoteCommandOptions = []string{
+	"-o", "BatchMode=yes",
+	"-o", "ConnectTimeout=10",
+	"-T",
+}
+
+// RunRemoteCommand 在主机上非交互地执行一条命令，stdout 与 stderr 都写入 out
+// 返回远程命令的退出码；ssh 无法启动或被取消时返回 -1 和对应错误
+func RunRemoteCommand(ctx context.Context, host SSHHost, command string, out io.Writer) (int, error) {
+	args, err := runCommandArgs(host, command)
+	if err != nil {
+		return -1, err
+	}
+
+	cmd := exec.CommandContext(ctx, "ssh", args...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return -1, ctx.Err()
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+// runCommandArgs 构建非交互执行远程命令的 ssh 参数（不含 ssh 本身），并预校验参数
+func runCommandArgs(host SSHHost, command string) ([]string, error) {
+	if command == "" {
+		return nil, fmt.Errorf("%s", i18n.T(i18n.RemoteCommandRequired))
+	}
+
+	args := append([]string{}, remoteCommandOptions...)
+	args = append(args, noRemoteCommandArgs...)
+	args = append(args, hostArgs(host)...)
+	args = append(args, host.Target())
+
+	if err := validateSSHCommand(args); err != nil {
+		return nil, fmt.Errorf("%s", i18n.TWithArgs(i18n.InvalidSSHCommand, err))
+	}
+	return append(args, command), nil
+}
+
+// SubsystemCommand 构建通过系统 ssh 启动远程子系统（如 sftp）的命令
+// 使用系统 ssh 可以复用用户的 ssh-agent、known_hosts 和密码提示
+func SubsystemCommand(host SSHHost, subsystem string) *exec.Cmd {
+	args := append(append([]string{}, noRemoteCommandArgs...), hostArgs(host)...)
+	args = append(args, "-s", host.Target(), subsystem)
+	return exec.Command("ssh", args...)
+}
@@ -0,0 +1,50 @@
+package ssh
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+// 配置了 RemoteCommand 的主机在命令行指定命令或子系统时必须带上 RemoteCommand=none
+func TestCommandLineOverridesRemoteCommand(t *testing.T) {
+	if _, err := exec.LookPath("ssh"); err != nil {
+		t.Skip("ssh not installed")
+	}
+	host := SSHHost{Host: "web-1", HostName: "10.0.0.1", User: "deploy", RemoteCommand: "tmux new -A -s main"}
+
+	interactive, err := CommandArgs(host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"ssh", "-l", "deploy", "-o", "RequestTTY=yes", "-o", "RemoteCommand=tmux new -A -s main", "10.0.0.1"}
+	if !reflect.DeepEqual(interactive, want) {
+		t.Errorf("CommandArgs() = %q, want %q", interactive, want)
+	}
+
+	withCommand := host
+	withCommand.Command = []string{"uptime"}
+	got, err := CommandArgs(withCommand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"ssh", "-l", "deploy", "-o", "RemoteCommand=none", "10.0.0.1", "uptime"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CommandArgs() with command = %q, want %q", got, want)
+	}
+
+	got, err = runCommandArgs(host, "uptime")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = append(append([]string{}, remoteCommandOptions...), "-o", "RemoteCommand=none", "-l", "deploy", "10.0.0.1", "uptime")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("runCommandArgs() = %q, want %q", got, want)
+	}
+
+	got = SubsystemCommand(host, "sftp").Args
+	want = []string{"ssh", "-o", "RemoteCommand=none", "-l", "deploy", "-s", "10.0.0.1", "sftp"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SubsystemCommand() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,116 @@
+package ssh
+
+import (
+	"fmt"
+	"strings"
+
+	"sshgo/i18n"
+)
+
+// RequestTTY 的可选值
+var requestTTYValues = []string{"yes", "no", "force", "auto"}
+
+// ValidateRequestTTY 校验 RequestTTY 取值（空表示未设置）
+func ValidateRequestTTY(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, v := range requestTTYValues {
+		if strings.EqualFold(value, v) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s", i18n.TWithArgs(i18n.InvalidRequestTTY, value))
+}
+
+// noRemoteCommandArgs 命令行指定了远程命令或子系统时覆盖配置中的 RemoteCommand，
+// 否则 ssh 会报 "Cannot execute command-line and remote command." 并退出
+var noRemoteCommandArgs = []string{"-o", "RemoteCommand=none"}
+
+// remoteCommandArgs 构建远程命令相关的 ssh 参数
+// 配置了 RemoteCommand 但未指定 RequestTTY 时请求伪终端，保证 sudo -i、tmux 等交互命令可用
+func remoteCommandArgs(host SSHHost) []string {
+	if host.RemoteCommand == "" {
+		if host.RequestTTY == "" {
+			return nil
+		}
+		return []string{"-o", "RequestTTY=" + host.RequestTTY}
+	}
+	tty := host.RequestTTY
+	if tty == "" {
+		tty = "yes"
+	}
+	return []string{"-o", "RequestTTY=" + tty, "-o", "RemoteCommand=" + host.RemoteCommand}
+}
+
+// SetRemoteCommand 设置主机的 RemoteCommand 与 RequestTTY，配置文件只写入一次
+// 值为空时删除对应指令
+func SetRemoteCommand(host, command, requestTTY string) error {
+	if err := ValidateRequestTTY(requestTTY); err != nil {
+		return err
+	}
+
+	configPath := GetSSHConfigPath()
+	content, err := readFileOrEmpty(configPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T(i18n.ReadConfigFileFailed), err)
+	}
+
+	output, err := setRemoteCommandContent(content, host, command, requestTTY)
+	if err != nil {
+		return err
+	}
+	if output == content {
+		return nil
+	}
+
+	if err := WriteFileWithBackup(configPath, []byte(output)); err != nil {
+		return fmt.Errorf(i18n.T(i18n.WriteConfigFileFailed), err)
+	}
+	return nil
+}
+
+// setRemoteCommandContent 在配置文本中更新主机的 RemoteCommand 与 RequestTTY
+func setRemoteCommandContent(content, host, command, requestTTY string) (string, error) {
+	if findHostBlock(splitConfigBlocks(content), host) < 0 {
+		return "", fmt.Errorf("%s", i18n.TWithArgs(i18n.HostBlockNotFound, host))
+	}
+
+	directives := []struct{ name, value string }{
+		{"RemoteCommand", command},
+		{"RequestTTY", requestTTY},
+	}
+	for _, d := range directives {
+		if d.value == "" {
+			content = removeHostDirectiveContent(content, host, d.name)
+		} else {
+			content = updateHostDirectiveContent(content, host, d.name, d.value)
+		}
+	}
+	return content, nil
+}
+
+// removeHostDirectiveContent 删除主机配置块中的某个指令
+func removeHostDirectiveContent(content, host, directive string) string {
+	blocks := splitConfigBlocks(content)
+	index := findHostBlock(blocks, host)
+	if index < 0 {
+		return content
+	}
+
+	b := blocks[index]
+	var body []string
+	for _, line := range b.body {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && strings.EqualFold(fields[0], directive) {
+			continue
+		}
+		body = append(body, line)
+	}
+	if len(body) == len(b.body) {
+		return content
+	}
+	b.body = body
+	blocks[index] = b
+	return joinConfigBlocks(blocks)
+}
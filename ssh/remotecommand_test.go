@@ -0,0 +1,53 @@
+package ssh
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetRemoteCommandContent(t *testing.T) {
+	content := "Host web-1\n    User deploy\n"
+
+	got, err := setRemoteCommandContent(content, "web-1", "tmux new -A -s main", "force")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Host web-1\n    User deploy\n    RemoteCommand tmux new -A -s main\n    RequestTTY force\n"
+	if got != want {
+		t.Errorf("setRemoteCommandContent() =\n%s\nwant:\n%s", got, want)
+	}
+
+	hosts := parseTestConfig(t, got)
+	if len(hosts) != 1 || hosts[0].RemoteCommand != "tmux new -A -s main" || hosts[0].RequestTTY != "force" {
+		t.Errorf("parsed hosts = %+v, want RemoteCommand and RequestTTY", hosts)
+	}
+
+	got, err = setRemoteCommandContent(got, "web-1", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != content {
+		t.Errorf("setRemoteCommandContent() with empty command =\n%s\nwant:\n%s", got, content)
+	}
+
+	if _, err := setRemoteCommandContent(content, "missing", "sudo -i", ""); err == nil {
+		t.Error("expected error for missing host block")
+	}
+}
+
+func TestRemoteCommandArgs(t *testing.T) {
+	tests := []struct {
+		host SSHHost
+		want []string
+	}{
+		{SSHHost{}, nil},
+		{SSHHost{RemoteCommand: "sudo -i"}, []string{"-o", "RequestTTY=yes", "-o", "RemoteCommand=sudo -i"}},
+		{SSHHost{RemoteCommand: "uptime", RequestTTY: "no"}, []string{"-o", "RequestTTY=no", "-o", "RemoteCommand=uptime"}},
+		{SSHHost{RequestTTY: "force"}, []string{"-o", "RequestTTY=force"}},
+	}
+	for _, tt := range tests {
+		if got := remoteCommandArgs(tt.host); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("remoteCommandArgs(%+v) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
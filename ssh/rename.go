@@ -0,0 +1,202 @@
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sshgo/i18n"
+)
+
+// ConfiguredAliases 返回所有配置文件中 Host 行声明的主机模式
+func ConfiguredAliases() (map[string]bool, error) {
+	aliases := make(map[string]bool)
+	for _, path := range GetAllConfigPaths() {
+		content, err := readFileOrEmpty(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range splitConfigBlocks(content) {
+			for _, h := range b.hosts {
+				aliases[h] = true
+			}
+		}
+	}
+	return aliases, nil
+}
+
+// checkAliasCollision 检查新的主机模式是否与现有别名冲突（ignore 中的模式除外）
+func checkAliasCollision(patterns []string, ignore []string) error {
+	if len(patterns) == 0 {
+		return fmt.Errorf("%s", i18n.T(i18n.AliasRequired))
+	}
+
+	aliases, err := ConfiguredAliases()
+	if err != nil {
+		return fmt.Errorf(i18n.T(i18n.ReadConfigFileFailed), err)
+	}
+	for _, h := range ignore {
+		delete(aliases, h)
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range patterns {
+		if aliases[p] || seen[p] {
+			return fmt.Errorf("%s", i18n.TWithArgs(i18n.AliasAlreadyExists, p))
+		}
+		seen[p] = true
+	}
+	return nil
+}
+
+// RenameHost 重命名主机：替换 Host 行中的模式列表，并同步更新 known_hosts 中的别名
+// oldHost 可以是单个模式或完整的模式列表，newHost 为新的模式列表（空格分隔）
+func RenameHost(oldHost, newHost string) error {
+	configPath := GetSSHConfigPath()
+	content, err := readFileOrEmpty(configPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T(i18n.ReadConfigFileFailed), err)
+	}
+
+	blocks := splitConfigBlocks(content)
+	index := findHostBlock(blocks, oldHost)
+	if index < 0 {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.HostBlockNotFound, oldHost))
+	}
+
+	oldPatterns := blocks[index].hosts
+	newPatterns := strings.Fields(newHost)
+	if err := checkAliasCollision(newPatterns, oldPatterns); err != nil {
+		return err
+	}
+
+	b := blocks[index]
+	b.header = hostHeaderIndent(b.header) + "Host " + strings.Join(newPatterns, " ")
+	b.hosts = newPatterns
+	blocks[index] = b
+
+	if err := WriteFileWithBackup(configPath, []byte(joinConfigBlocks(blocks))); err != nil {
+		return fmt.Errorf(i18n.T(i18n.WriteConfigFileFailed), err)
+	}
+
+	// 按位置对应更新 known_hosts 中的别名（通配符模式跳过）
+	renames := make(map[string]string)
+	for i := 0; i < len(oldPatterns) && i < len(newPatterns); i++ {
+		if oldPatterns[i] != newPatterns[i] && !strings.ContainsAny(oldPatterns[i], "*?!") {
+			renames[oldPatterns[i]] = newPatterns[i]
+		}
+	}
+	return renameKnownHosts(renames)
+}
+
+// DuplicateHost 复制主机配置块，新块使用 newHost 作为 Host 行，插入在原块之后
+func DuplicateHost(host, newHost string) error {
+	configPath := GetSSHConfigPath()
+	content, err := readFileOrEmpty(configPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T(i18n.ReadConfigFileFailed), err)
+	}
+
+	blocks := splitConfigBlocks(content)
+	index := findHostBlock(blocks, host)
+	if index < 0 {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.HostBlockNotFound, host))
+	}
+
+	newPatterns := strings.Fields(newHost)
+	if err := checkAliasCollision(newPatterns, nil); err != nil {
+		return err
+	}
+
+	src := blocks[index]
+	body := append([]string(nil), src.body...)
+	// 确保原块与新块之间有空行分隔
+	if len(body) == 0 || strings.TrimSpace(body[len(body)-1]) != "" {
+		src.body = append(src.body, "")
+		blocks[index] = src
+		body = append(body, "")
+	}
+	dup := configBlock{
+		header: hostHeaderIndent(src.header) + "Host " + strings.Join(newPatterns, " "),
+		hosts:  newPatterns,
+		body:   body,
+	}
+
+	blocks = append(blocks[:index+1], append([]configBlock{dup}, blocks[index+1:]...)...)
+
+	if err := WriteFileWithBackup(configPath, []byte(joinConfigBlocks(blocks))); err != nil {
+		return fmt.Errorf(i18n.T(i18n.WriteConfigFileFailed), err)
+	}
+	return nil
+}
+
+// renameKnownHosts 将 known_hosts 中的主机名按映射替换（保留 [host]:port 格式）
+func renameKnownHosts(renames map[string]string) error {
+	if len(renames) == 0 {
+		return nil
+	}
+
+	knownHostsPath := GetKnownHostsPath()
+	content, err := os.ReadFile(knownHostsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf(i18n.T(i18n.ReadKnownHostsFailed), err)
+	}
+
+	output := renameKnownHostEntries(string(content), renames)
+	if output == string(content) {
+		return nil
+	}
+
+	if err := WriteFileWithBackup(knownHostsPath, []byte(output)); err != nil {
+		return fmt.Errorf(i18n.T(i18n.WriteKnownHostsFailed), err)
+	}
+	return nil
+}
+
+// renameKnownHostEntries 在 known_hosts 文本中替换主机名
+func renameKnownHostEntries(content string, renames map[string]string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// 标记行（@cert-authority / @revoked）的主机字段在第二列
+		fields := strings.Fields(line)
+		hostIndex := 0
+		if strings.HasPrefix(fields[0], "@") {
+			hostIndex = 1
+		}
+		if len(fields) <= hostIndex+1 {
+			continue
+		}
+
+		names := strings.Split(fields[hostIndex], ",")
+		changed := false
+		for j, name := range names {
+			bare, port := name, ""
+			if strings.HasPrefix(name, "[") {
+				if end := strings.Index(name, "]"); end > 0 {
+					bare, port = name[1:end], name[end+1:]
+				}
+			}
+			if newName, ok := renames[bare]; ok {
+				if port != "" {
+					names[j] = "[" + newName + "]" + port
+				} else {
+					names[j] = newName
+				}
+				changed = true
+			}
+		}
+		if changed {
+			fields[hostIndex] = strings.Join(names, ",")
+			lines[i] = strings.Join(fields, " ")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,80 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// setupTestHome 创建临时主目录并写入 config / known_hosts
+func setupTestHome(t *testing.T, config, knownHosts string) {
+	t.Helper()
+	home := t.TempDir()
+	if runtime.GOOS == "windows" {
+		t.Setenv("USERPROFILE", home)
+	} else {
+		t.Setenv("HOME", home)
+	}
+	dir := filepath.Join(home, ".ssh")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config"), []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "known_hosts"), []byte(knownHosts), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRenameHost(t *testing.T) {
+	setupTestHome(t,
+		"Host web-1 web1.internal\n    User deploy\n\nHost db\n    Port 2222\n",
+		"web-1 ssh-ed25519 AAAA\n[web-1]:2222,10.0.0.1 ssh-rsa BBBB\ndb ssh-ed25519 CCCC\n")
+
+	if err := RenameHost("web-1 web1.internal", "db"); err == nil {
+		t.Fatal("RenameHost() to an existing alias should fail")
+	}
+
+	if err := RenameHost("web-1", "web-2 web2.internal"); err != nil {
+		t.Fatalf("RenameHost() error = %v", err)
+	}
+
+	wantConfig := "Host web-2 web2.internal\n    User deploy\n\nHost db\n    Port 2222\n"
+	if got := readTestFile(t, GetSSHConfigPath()); got != wantConfig {
+		t.Errorf("config =\n%s\nwant:\n%s", got, wantConfig)
+	}
+	wantKnown := "web-2 ssh-ed25519 AAAA\n[web-2]:2222,10.0.0.1 ssh-rsa BBBB\ndb ssh-ed25519 CCCC\n"
+	if got := readTestFile(t, GetKnownHostsPath()); got != wantKnown {
+		t.Errorf("known_hosts =\n%s\nwant:\n%s", got, wantKnown)
+	}
+}
+
+func TestDuplicateHost(t *testing.T) {
+	setupTestHome(t, "Host web-1\n    HostName 10.0.0.1\n    User deploy\nHost db\n    Port 2222\n", "")
+
+	if err := DuplicateHost("web-1", "db"); err == nil {
+		t.Fatal("DuplicateHost() to an existing alias should fail")
+	}
+
+	if err := DuplicateHost("web-1", "web-2"); err != nil {
+		t.Fatalf("DuplicateHost() error = %v", err)
+	}
+
+	want := "Host web-1\n    HostName 10.0.0.1\n    User deploy\n\n" +
+		"Host web-2\n    HostName 10.0.0.1\n    User deploy\n\n" +
+		"Host db\n    Port 2222\n"
+	if got := readTestFile(t, GetSSHConfigPath()); got != want {
+		t.Errorf("config =\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,129 @@
+package ssh
+
+import (
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// FindHost 在主机列表中按别名查找主机，别名可以是 Host 行中的任意一个模式
+func FindHost(hosts []SSHHost, alias string) (SSHHost, bool) {
+	for _, h := range hosts {
+		if h.Host == alias {
+			return h, true
+		}
+		for _, pattern := range strings.Fields(h.Host) {
+			if pattern == alias {
+				return h, true
+			}
+		}
+	}
+	return SSHHost{}, false
+}
+
+// ResolveHost 解析 [user@]host 形式的目标：host 为配置中的别名时使用配置
+// （包括 HostName、Port、IdentityFile、ProxyJump），否则视为主机地址
+func ResolveHost(target string) (SSHHost, error) {
+	user, alias := "", target
+	if i := strings.LastIndex(target, "@"); i >= 0 {
+		user, alias = target[:i], target[i+1:]
+	}
+
+	hosts, err := ParseSSHConfig(GetSSHConfigPath())
+	if err != nil {
+		return SSHHost{}, err
+	}
+
+	host, ok := FindHost(hosts, alias)
+	if !ok {
+		host = ApplyUserDefaults(SSHHost{Host: alias, HostName: alias, Port: "22"}, hosts)
+	}
+	if user != "" {
+		host.User = user
+	}
+	return host, nil
+}
+
+// MatchKind 主机参数的匹配方式
+type MatchKind int
+
+const (
+	MatchNone   MatchKind = iota
+	MatchExact            // 别名或 HostName 完全一致
+	MatchPrefix           // 别名前缀
+	MatchFuzzy            // 模糊匹配
+)
+
+// isPattern 判断 Host 模式是否包含通配符
+func isPattern(alias string) bool {
+	return strings.ContainsAny(alias, "*?!")
+}
+
+// looksLikeAddress 判断参数是否像 IP 地址或完整域名，这类参数不做模糊匹配，
+// 避免把未配置的地址误判为某个别名
+func looksLikeAddress(query string) bool {
+	return strings.ContainsAny(query, ".:")
+}
+
+// MatchHosts 依次按别名/HostName 精确匹配、别名前缀、模糊匹配查找主机，
+// 返回第一个有结果的阶段的所有候选（模糊匹配按得分排序）
+func MatchHosts(hosts []SSHHost, query string) ([]SSHHost, MatchKind) {
+	if query == "" {
+		return nil, MatchNone
+	}
+	if h, ok := FindHost(hosts, query); ok {
+		return []SSHHost{h}, MatchExact
+	}
+
+	var candidates []SSHHost
+	for _, h := range hosts {
+		if h.HostName == query {
+			candidates = append(candidates, h)
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates, MatchExact
+	}
+
+	lower := strings.ToLower(query)
+	for _, h := range hosts {
+		for _, alias := range strings.Fields(h.Host) {
+			if isPattern(alias) {
+				continue
+			}
+			if strings.HasPrefix(strings.ToLower(alias), lower) {
+				candidates = append(candidates, h)
+				break
+			}
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates, MatchPrefix
+	}
+
+	if looksLikeAddress(query) {
+		return nil, MatchNone
+	}
+	var aliases []string
+	var owners []SSHHost // aliases 中每个别名所属的主机
+	for _, h := range hosts {
+		for _, alias := range strings.Fields(h.Host) {
+			if !isPattern(alias) {
+				aliases = append(aliases, alias)
+				owners = append(owners, h)
+			}
+		}
+	}
+	seen := make(map[string]bool)
+	for _, m := range fuzzy.Find(query, aliases) {
+		h := owners[m.Index]
+		if !seen[h.Host] {
+			seen[h.Host] = true
+			candidates = append(candidates, h)
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates, MatchFuzzy
+	}
+	return nil, MatchNone
+}
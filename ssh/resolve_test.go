@@ -0,0 +1,46 @@
+package ssh
+
+import "testing"
+
+func TestMatchHosts(t *testing.T) {
+	hosts := []SSHHost{
+		{Host: "prod-web-1", HostName: "10.0.0.1"},
+		{Host: "prod-web-2", HostName: "10.0.0.2"},
+		{Host: "prod-db", HostName: "10.0.1.1"},
+		{Host: "staging web-stg", HostName: "10.0.2.1"},
+		{Host: "*"},
+	}
+
+	tests := []struct {
+		query string
+		kind  MatchKind
+		want  []string
+	}{
+		{"prod-db", MatchExact, []string{"prod-db"}},
+		{"web-stg", MatchExact, []string{"staging web-stg"}},
+		{"10.0.0.2", MatchExact, []string{"prod-web-2"}},
+		{"prod-d", MatchPrefix, []string{"prod-db"}},
+		{"prod-we", MatchPrefix, []string{"prod-web-1", "prod-web-2"}},
+		{"stg", MatchFuzzy, []string{"staging web-stg"}},
+		{"pdb", MatchFuzzy, []string{"prod-db", "prod-web-2", "prod-web-1"}},
+		{"10.9.9.9", MatchNone, nil},
+		{"zzz", MatchNone, nil},
+	}
+	for _, tt := range tests {
+		got, kind := MatchHosts(hosts, tt.query)
+		var aliases []string
+		for _, h := range got {
+			aliases = append(aliases, h.Host)
+		}
+		if kind != tt.kind || len(aliases) != len(tt.want) {
+			t.Errorf("MatchHosts(%q) = %q (kind %d), want %q (kind %d)", tt.query, aliases, kind, tt.want, tt.kind)
+			continue
+		}
+		for i := range aliases {
+			if aliases[i] != tt.want[i] {
+				t.Errorf("MatchHosts(%q) = %q, want %q", tt.query, aliases, tt.want)
+				break
+			}
+		}
+	}
+}
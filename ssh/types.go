@@ -2,9 +2,42 @@ package ssh
 
 // SSHHost 表示一个SSH主机配置
 type SSHHost struct {
-	Host     string
-	HostName string
-	User     string
-	Port     string
-	KeyFile  string
+	Host      string
+	HostName  string
+	User      string
+	Port      string
+	KeyFile   string
+	ProxyJump string   // 跳板机，传给 ssh -J
+	Tags      []string // 来自 #sshgo: 注释的标签
+	Group     string   // 来自 #sshgo: 注释的分组
+
+	RemoteCommand string // 连接后在远程执行的命令
+	RequestTTY    string // 是否请求伪终端：yes / no / force / auto
+
+	Options []string // 命令行传入的额外 ssh 选项（不写入配置）
+	Command []string // 命令行传入的远程命令（不写入配置）
+
+	InheritedUser string // 未配置 User 时从匹配的通配符块继承的用户（不写入配置）
+	Source        string // 定义该主机的配置文件，来自 known_hosts 的主机为空
+}
+
+// EffectiveUser 连接时使用的用户：主机配置或命令行指定的 User，否则为继承自通配符块的用户
+func (h SSHHost) EffectiveUser() string {
+	if h.User != "" {
+		return h.User
+	}
+	return h.InheritedUser
+}
+
+// Target 返回实际连接的目标地址（未配置 HostName 时使用别名）
+func (h SSHHost) Target() string {
+	if h.HostName != "" {
+		return h.HostName
+	}
+	return h.Host
+}
+
+// IsPattern 是否为通配符配置块（如 Host *.example.com），不能直接连接
+func (h SSHHost) IsPattern() bool {
+	return isPattern(h.Host)
 }
@@ -0,0 +1,122 @@
+package ssh
+
+import (
+	"os"
+	"os/user"
+	"strings"
+
+	"sshgo/i18n"
+)
+
+// matchPattern 按 ssh_config 规则匹配主机模式（支持 * 和 ?，不区分大小写）
+func matchPattern(pattern, name string) bool {
+	pattern, name = strings.ToLower(pattern), strings.ToLower(name)
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(name); i >= 0; i-- {
+				if matchPattern(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if name == "" {
+				return false
+			}
+		default:
+			if name == "" || pattern[0] != name[0] {
+				return false
+			}
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return name == ""
+}
+
+// blockMatches 判断 Host 行的模式列表是否匹配主机名：至少一个模式匹配，且没有否定模式（!pattern）匹配
+func blockMatches(patterns []string, name string) bool {
+	matched := false
+	for _, p := range patterns {
+		if negated, ok := strings.CutPrefix(p, "!"); ok {
+			if matchPattern(negated, name) {
+				return false
+			}
+			continue
+		}
+		if matchPattern(p, name) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+// ApplyUserDefaults 主机没有配置 User 时，从匹配的通配符块继承到 InheritedUser（User 保持不变）：
+// 先按文件顺序查找匹配的模式块（如 Host web-*），最后是 Host *
+func ApplyUserDefaults(host SSHHost, hosts []SSHHost) SSHHost {
+	if host.User != "" {
+		return host
+	}
+	name := host.Host
+	for _, alias := range strings.Fields(host.Host) {
+		if !isPattern(alias) {
+			name = alias
+			break
+		}
+	}
+
+	fallback := ""
+	for _, h := range hosts {
+		if h.User == "" || h.Host == host.Host || !isPattern(h.Host) {
+			continue
+		}
+		if !blockMatches(strings.Fields(h.Host), name) {
+			continue
+		}
+		if h.Host == "*" {
+			if fallback == "" {
+				fallback = h.User
+			}
+			continue
+		}
+		host.InheritedUser = h.User
+		return host
+	}
+	host.InheritedUser = fallback
+	return host
+}
+
+// LocalUsername 返回本地登录用户名（ssh 未指定用户时使用的用户名），无法获取时返回空
+func LocalUsername() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		// Windows 下为 DOMAIN\user
+		name := u.Username
+		if i := strings.LastIndex(name, `\`); i >= 0 {
+			name = name[i+1:]
+		}
+		return name
+	}
+	for _, env := range []string{"USER", "USERNAME", "LOGNAME"} {
+		if name := os.Getenv(env); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// DefaultUsername 未配置用户时使用的用户名：本地用户名，无法获取时为 root
+func DefaultUsername() string {
+	if name := LocalUsername(); name != "" {
+		return name
+	}
+	return i18n.T(i18n.DefaultUsername)
+}
+
+// ResolveUser 返回连接时实际使用的用户：命令行或配置中的 User（含继承自通配符块的），否则为本地用户名
+func ResolveUser(host SSHHost) SSHHost {
+	host.User = host.EffectiveUser()
+	if host.User == "" {
+		host.User = LocalUsername()
+	}
+	return host
+}
@@ -0,0 +1,64 @@
+package ssh
+
+import "testing"
+
+func TestApplyUserDefaults(t *testing.T) {
+	hosts := parseTestConfig(t, `Host *
+    User fallback
+
+Host web-1
+    HostName 10.0.0.1
+
+Host db-1
+    User dba
+
+Host web-* !web-legacy
+    User deploy
+
+Host web-legacy
+    HostName 10.0.0.9
+`)
+	users := make(map[string]string)
+	for _, h := range hosts {
+		users[h.Host] = ApplyUserDefaults(h, hosts).EffectiveUser()
+	}
+
+	want := map[string]string{
+		"web-1":      "deploy",   // 模式块优先于 Host *
+		"db-1":       "dba",      // 主机自身配置优先
+		"web-legacy": "fallback", // 被否定模式排除，使用 Host *
+	}
+	for alias, user := range want {
+		if users[alias] != user {
+			t.Errorf("user of %s = %q, want %q", alias, users[alias], user)
+		}
+	}
+
+	literal := ApplyUserDefaults(SSHHost{Host: "web-9.example.com"}, hosts)
+	if literal.EffectiveUser() != "deploy" {
+		t.Errorf("user of unconfigured host = %q, want deploy", literal.EffectiveUser())
+	}
+	// 继承的用户不写入 User，以免详情、修改用户与导出把它当作主机自身的配置
+	if literal.User != "" {
+		t.Errorf("User of unconfigured host = %q, want empty", literal.User)
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*", "anything", true},
+		{"web-*", "web-1", true},
+		{"web-*", "db-1", false},
+		{"10.0.?.1", "10.0.3.1", true},
+		{"10.0.?.1", "10.0.33.1", false},
+		{"WEB-*", "web-1", true},
+	}
+	for _, tt := range tests {
+		if got := matchPattern(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchPattern(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
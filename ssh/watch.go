@@ -0,0 +1,121 @@
+package ssh
+
+import (
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"sshgo/i18n"
+)
+
+// loadedFiles sshgo 最近一次读取或写入的配置文件、Include 的文件与 known_hosts 的内容
+// 用于检测文件是否在读取后被外部修改
+var (
+	loadedMu    sync.Mutex
+	loadedFiles = make(map[string]string)
+)
+
+// rememberFile 记录文件当前被 sshgo 看到的内容（文件不存在时为空）
+func rememberFile(path, content string) {
+	loadedMu.Lock()
+	defer loadedMu.Unlock()
+	loadedFiles[path] = content
+}
+
+// loadedContent 返回记录的文件内容，未读取过时 ok 为 false
+func loadedContent(path string) (content string, ok bool) {
+	loadedMu.Lock()
+	defer loadedMu.Unlock()
+	content, ok = loadedFiles[path]
+	return content, ok
+}
+
+// WatchedFiles 返回需要监视变化的文件：读取过的配置文件、Include 的文件与 known_hosts，按路径排序
+func WatchedFiles() []string {
+	loadedMu.Lock()
+	defer loadedMu.Unlock()
+	paths := make([]string, 0, len(loadedFiles))
+	for path := range loadedFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// FileStamp 文件的修改时间与大小，用于低成本地发现变化；文件不存在时为零值
+type FileStamp struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// StatFiles 返回各文件当前的 FileStamp
+func StatFiles(paths []string) map[string]FileStamp {
+	stamps := make(map[string]FileStamp, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			stamps[path] = FileStamp{ModTime: info.ModTime(), Size: info.Size()}
+		} else {
+			stamps[path] = FileStamp{}
+		}
+	}
+	return stamps
+}
+
+// ExternallyModified 返回内容与 sshgo 记录的不一致（即被其他程序修改过）的文件
+func ExternallyModified(paths []string) []string {
+	var modified []string
+	for _, path := range paths {
+		if CheckUnchanged(path) != nil {
+			modified = append(modified, path)
+		}
+	}
+	return modified
+}
+
+// ConflictError 文件在 sshgo 读取之后被外部修改，继续写入可能覆盖他人的修改
+type ConflictError struct {
+	Path string
+}
+
+func (e *ConflictError) Error() string {
+	return i18n.TWithArgs(i18n.ConfigModifiedExternally, e.Path)
+}
+
+// CheckUnchanged 检查文件自 sshgo 上次读取或写入后是否被外部修改；未读取过的文件不检查
+func CheckUnchanged(paths ...string) error {
+	for _, path := range paths {
+		loaded, ok := loadedContent(path)
+		if !ok {
+			continue
+		}
+		current, err := readFileOrEmpty(path)
+		if err != nil {
+			return err
+		}
+		if current != loaded {
+			return &ConflictError{Path: path}
+		}
+	}
+	return nil
+}
+
+// ExternalChanges 返回文件自 sshgo 上次读取后被外部修改的 diff
+func ExternalChanges(path string) (string, error) {
+	loaded, _ := loadedContent(path)
+	current, err := readFileOrEmpty(path)
+	if err != nil {
+		return "", err
+	}
+	return UnifiedDiff(path, path, loaded, current), nil
+}
+
+// AcceptChanges 接受文件当前的内容，之后的修改将在该内容的基础上进行
+func AcceptChanges(path string) error {
+	current, err := readFileOrEmpty(path)
+	if err != nil {
+		return err
+	}
+	rememberFile(path, current)
+	return nil
+}
@@ -0,0 +1,80 @@
+package ssh
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseInclude(t *testing.T) {
+	setupTestHome(t, "Include conf.d/*.conf\n\nHost web-1\n    User deploy\n", "")
+	dir := filepath.Dir(GetSSHConfigPath())
+	if err := os.MkdirAll(filepath.Join(dir, "conf.d"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	included := filepath.Join(dir, "conf.d", "db.conf")
+	if err := os.WriteFile(included, []byte("Host db-1\n    Port 2222\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	hosts, err := parseSingleConfigFile(GetSSHConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 2 || hosts[0].Host != "web-1" || hosts[0].User != "deploy" ||
+		hosts[1].Host != "db-1" || hosts[1].Port != "2222" {
+		t.Errorf("parsed hosts = %+v, want web-1 followed by db-1 from the included file", hosts)
+	}
+
+	watched := WatchedFiles()
+	found := false
+	for _, path := range watched {
+		found = found || path == included
+	}
+	if !found {
+		t.Errorf("WatchedFiles() = %v, want it to contain %s", watched, included)
+	}
+}
+
+func TestCheckUnchanged(t *testing.T) {
+	setupTestHome(t, "Host web-1\n    User deploy\n", "")
+	path := GetSSHConfigPath()
+	if _, err := parseSingleConfigFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckUnchanged(path); err != nil {
+		t.Fatalf("CheckUnchanged() after load = %v, want nil", err)
+	}
+
+	// sshgo 自己的写入不算冲突
+	if err := SaveUserToConfig("web-1", "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckUnchanged(path); err != nil {
+		t.Fatalf("CheckUnchanged() after own write = %v, want nil", err)
+	}
+	if modified := ExternallyModified([]string{path}); len(modified) != 0 {
+		t.Errorf("ExternallyModified() after own write = %v, want none", modified)
+	}
+
+	// 其他程序的修改
+	external := readTestFile(t, path) + "\nHost db-1\n"
+	if err := os.WriteFile(path, []byte(external), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var conflict *ConflictError
+	if err := CheckUnchanged(path); !errors.As(err, &conflict) || conflict.Path != path {
+		t.Fatalf("CheckUnchanged() after external edit = %v, want ConflictError for %s", err, path)
+	}
+	if diff, err := ExternalChanges(path); err != nil || diff == "" {
+		t.Errorf("ExternalChanges() = %q, %v, want a diff", diff, err)
+	}
+
+	if err := AcceptChanges(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckUnchanged(path); err != nil {
+		t.Errorf("CheckUnchanged() after AcceptChanges = %v, want nil", err)
+	}
+}
@@ -0,0 +1,140 @@
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/ssh"
+	"sshgo/xdg"
+)
+
+// 连接历史文件名（位于状态目录，每行一条 JSON 记录）
+const historyFileName = "history.jsonl"
+
+// 连接历史保留的最大条目数
+const maxHistoryEntries = 1000
+
+// HistoryEntry 一次连接的记录
+type HistoryEntry struct {
+	Alias     string    `json:"alias"`
+	HostName  string    `json:"hostname"`
+	User      string    `json:"user,omitempty"`
+	Port      string    `json:"port,omitempty"`
+	KeyFile   string    `json:"key_file,omitempty"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	ExitCode  int       `json:"exit_code"`
+	Error     string    `json:"error,omitempty"`
+	Recording string    `json:"recording,omitempty"` // 会话录制文件路径
+	Command   string    `json:"command,omitempty"`   // 连接后执行的远程命令
+}
+
+// Duration 会话持续时间
+func (e HistoryEntry) Duration() time.Duration {
+	return e.End.Sub(e.Start).Round(time.Second)
+}
+
+// Summary 格式化连接时间、时长和结果
+func (e HistoryEntry) Summary() string {
+	start := e.Start.Format("2006-01-02 15:04:05")
+	if e.ExitCode < 0 && e.Error != "" {
+		return i18n.TWithArgs(i18n.HistoryEntryError, start, e.Error)
+	}
+	return i18n.TWithArgs(i18n.HistoryEntryDesc, start, e.Duration(), e.ExitCode)
+}
+
+// Host 将记录还原为主机配置（用于重新连接）
+func (e HistoryEntry) Host() ssh.SSHHost {
+	return ssh.SSHHost{
+		Host:     e.Alias,
+		HostName: e.HostName,
+		User:     e.User,
+		Port:     e.Port,
+		KeyFile:  e.KeyFile,
+
+		RemoteCommand: e.Command,
+	}
+}
+
+// AppendHistory 追加一条连接记录，超出上限时丢弃最旧的记录
+func AppendHistory(entry HistoryEntry) error {
+	path, err := xdg.StatePath(historyFileName)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return trimHistory(path)
+}
+
+// trimHistory 历史记录超过上限两倍时截断为最近的 maxHistoryEntries 条
+func trimHistory(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) <= 2*maxHistoryEntries {
+		return nil
+	}
+	lines = lines[len(lines)-maxHistoryEntries:]
+	return ssh.WriteFileAtomic(path, []byte(strings.Join(lines, "\n")+"\n"), 0600)
+}
+
+// LoadHistory 读取连接历史，按时间从新到旧排序；alias 非空时只返回该主机的记录
+func LoadHistory(alias string) ([]HistoryEntry, error) {
+	path, err := xdg.StatePath(historyFileName)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e HistoryEntry
+		// 跳过损坏的行
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if alias != "" && e.Alias != alias {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// 反转为从新到旧
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
@@ -0,0 +1,161 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"sshgo/ssh"
+	"sshgo/xdg"
+)
+
+// 使用记录文件名（位于状态目录）
+const usageFileName = "usage.json"
+
+// HostUsage 单个主机的使用记录
+type HostUsage struct {
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"last_used"`
+	Favorite bool      `json:"favorite,omitempty"`
+}
+
+// Usage 所有主机的使用记录及列表排序偏好
+type Usage struct {
+	Hosts    map[string]*HostUsage `json:"hosts"`
+	SortMode string                `json:"sort_mode,omitempty"`
+}
+
+// LoadUsage 读取使用记录，文件不存在时返回空记录
+func LoadUsage() (*Usage, error) {
+	u := &Usage{Hosts: make(map[string]*HostUsage)}
+
+	path, err := xdg.StatePath(usageFileName)
+	if err != nil {
+		return u, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return u, nil
+		}
+		return u, err
+	}
+	if err := json.Unmarshal(data, u); err != nil {
+		return u, err
+	}
+	if u.Hosts == nil {
+		u.Hosts = make(map[string]*HostUsage)
+	}
+	return u, nil
+}
+
+// Save 保存使用记录
+func (u *Usage) Save() error {
+	path, err := xdg.StatePath(usageFileName)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(u, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ssh.WriteFileAtomic(path, data, 0600)
+}
+
+// Get 获取主机的使用记录，没有记录时返回零值
+func (u *Usage) Get(alias string) HostUsage {
+	if h, ok := u.Hosts[alias]; ok {
+		return *h
+	}
+	return HostUsage{}
+}
+
+// entry 获取或创建主机的使用记录
+func (u *Usage) entry(alias string) *HostUsage {
+	h, ok := u.Hosts[alias]
+	if !ok {
+		h = &HostUsage{}
+		u.Hosts[alias] = h
+	}
+	return h
+}
+
+// RecordConnection 记录一次连接
+func (u *Usage) RecordConnection(alias string, at time.Time) {
+	h := u.entry(alias)
+	h.Count++
+	h.LastUsed = at
+}
+
+// ToggleFavorite 切换收藏状态，返回切换后的状态
+func (u *Usage) ToggleFavorite(alias string) bool {
+	h := u.entry(alias)
+	h.Favorite = !h.Favorite
+	return h.Favorite
+}
+
+// Frecency 根据使用次数和最近使用时间计算得分（越近、越频繁得分越高）
+func (u *Usage) Frecency(alias string, now time.Time) float64 {
+	h := u.Get(alias)
+	if h.Count == 0 {
+		return 0
+	}
+
+	age := now.Sub(h.LastUsed)
+	var weight float64
+	switch {
+	case age < time.Hour:
+		weight = 4
+	case age < 24*time.Hour:
+		weight = 2
+	case age < 7*24*time.Hour:
+		weight = 1
+	case age < 30*24*time.Hour:
+		weight = 0.5
+	default:
+		weight = 0.25
+	}
+	return float64(h.Count) * weight
+}
+
+// usageMu 串行化本进程内对使用记录文件的读取-修改-保存
+var usageMu sync.Mutex
+
+// update 重新读取使用记录，修改后保存并返回最新的记录
+// 每次修改都基于文件中的最新内容，不会覆盖其他进程（如另一个终端中的 sshgo）写入的记录
+func update(fn func(u *Usage)) (*Usage, error) {
+	usageMu.Lock()
+	defer usageMu.Unlock()
+
+	u, err := LoadUsage()
+	if err != nil {
+		return nil, err
+	}
+	fn(u)
+	return u, u.Save()
+}
+
+// RecordConnection 读取、更新并保存指定主机的连接记录
+func RecordConnection(alias string) error {
+	_, err := update(func(u *Usage) {
+		u.RecordConnection(alias, time.Now())
+	})
+	return err
+}
+
+// ToggleFavorite 读取、切换并保存主机的收藏状态，返回最新的记录与切换后的状态
+func ToggleFavorite(alias string) (*Usage, bool, error) {
+	var favorite bool
+	u, err := update(func(u *Usage) {
+		favorite = u.ToggleFavorite(alias)
+	})
+	return u, favorite, err
+}
+
+// SetSortMode 读取、修改并保存主机列表的排序方式，返回最新的记录
+func SetSortMode(mode string) (*Usage, error) {
+	return update(func(u *Usage) {
+		u.SortMode = mode
+	})
+}
@@ -0,0 +1,327 @@
+package transfer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"sshgo/i18n"
+
+	"github.com/pkg/sftp"
+)
+
+// Progress 传输进度
+type Progress struct {
+	File       string // 当前正在传输的文件
+	Done       int64  // 已传输字节数（所有文件）
+	Total      int64  // 总字节数（所有文件）
+	Files      int    // 已完成的文件数
+	TotalFiles int
+}
+
+// Percent 返回完成百分比（0-1）；传输中源文件变大时 Done 可能超过 Total
+func (p Progress) Percent() float64 {
+	if p.Total <= 0 {
+		if p.TotalFiles > 0 && p.Files >= p.TotalFiles {
+			return 1
+		}
+		return 0
+	}
+	return min(max(float64(p.Done)/float64(p.Total), 0), 1)
+}
+
+// Options 传输选项
+type Options struct {
+	// Resume 为 true 时，目标文件比源文件小且内容是源文件的开头则从断点继续传输，内容相同则跳过
+	// 目标文件内容与源文件不一致时重新传输
+	Resume bool
+	// Verify 为 true 时，每个文件传输后比较源文件与目标文件的 SHA-256
+	Verify bool
+	// Progress 进度回调，可以为空
+	Progress func(Progress)
+}
+
+// file 本地与远程文件的公共接口
+type file interface {
+	io.Reader
+	io.Writer
+	io.Seeker
+	io.Closer
+}
+
+// fileSystem 本地与远程文件系统的公共接口，使上传和下载共用同一套复制逻辑
+type fileSystem interface {
+	Stat(name string) (os.FileInfo, error)
+	ReadDir(name string) ([]os.FileInfo, error)
+	Open(name string) (file, error)
+	OpenWrite(name string, truncate bool) (file, error)
+	MkdirAll(name string) error
+	Join(elem ...string) string
+	Base(name string) string
+}
+
+// localFS 本地文件系统
+type localFS struct{}
+
+func (localFS) Stat(name string) (os.FileInfo, error) { return os.Stat(name) }
+func (localFS) Open(name string) (file, error)        { return os.Open(name) }
+func (localFS) MkdirAll(name string) error            { return os.MkdirAll(name, 0755) }
+func (localFS) Join(elem ...string) string            { return filepath.Join(elem...) }
+func (localFS) Base(name string) string               { return filepath.Base(name) }
+
+func (localFS) ReadDir(name string) ([]os.FileInfo, error) {
+	entries, err := os.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]os.FileInfo, 0, len(entries))
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (localFS) OpenWrite(name string, truncate bool) (file, error) {
+	flags := os.O_WRONLY | os.O_CREATE
+	if truncate {
+		flags |= os.O_TRUNC
+	}
+	return os.OpenFile(name, flags, 0644)
+}
+
+// remoteFS 远程 SFTP 文件系统
+type remoteFS struct {
+	client *sftp.Client
+}
+
+func (r *remoteFS) Stat(name string) (os.FileInfo, error)      { return r.client.Stat(name) }
+func (r *remoteFS) ReadDir(name string) ([]os.FileInfo, error) { return r.client.ReadDir(name) }
+func (r *remoteFS) Open(name string) (file, error)             { return r.client.Open(name) }
+func (r *remoteFS) MkdirAll(name string) error                 { return r.client.MkdirAll(name) }
+func (r *remoteFS) Join(elem ...string) string                 { return path.Join(elem...) }
+func (r *remoteFS) Base(name string) string                    { return path.Base(name) }
+
+func (r *remoteFS) OpenWrite(name string, truncate bool) (file, error) {
+	flags := os.O_WRONLY | os.O_CREATE
+	if truncate {
+		flags |= os.O_TRUNC
+	}
+	return r.client.OpenFile(name, flags)
+}
+
+// Upload 将本地文件或目录（递归）上传到远程目录 remoteDir 下
+func (s *Session) Upload(ctx context.Context, localPath, remoteDir string, opts Options) error {
+	return copyTree(ctx, localFS{}, localPath, s.remote, remoteDir, opts)
+}
+
+// Download 将远程文件或目录（递归）下载到本地目录 localDir 下
+func (s *Session) Download(ctx context.Context, remotePath, localDir string, opts Options) error {
+	return copyTree(ctx, s.remote, remotePath, localFS{}, localDir, opts)
+}
+
+// UploadFile 将本地文件上传为远程文件 remotePath（不做目录推断）
+func (s *Session) UploadFile(ctx context.Context, localPath, remotePath string, opts Options) error {
+	return copyTo(ctx, localFS{}, localPath, s.remote, remotePath, opts)
+}
+
+// DownloadFile 将远程文件下载为本地文件 localPath（不做目录推断）
+func (s *Session) DownloadFile(ctx context.Context, remotePath, localPath string, opts Options) error {
+	return copyTo(ctx, s.remote, remotePath, localFS{}, localPath, opts)
+}
+
+// copyJob 一个待复制的文件
+type copyJob struct {
+	src, dst string
+	size     int64
+}
+
+// copyTree 将 src 复制到 dstDir/<basename>，src 为目录时递归复制
+func copyTree(ctx context.Context, srcFS fileSystem, src string, dstFS fileSystem, dstDir string, opts Options) error {
+	return copyTo(ctx, srcFS, src, dstFS, dstFS.Join(dstDir, srcFS.Base(src)), opts)
+}
+
+// copyTo 将 src 复制为 dst，src 为目录时递归复制
+func copyTo(ctx context.Context, srcFS fileSystem, src string, dstFS fileSystem, dst string, opts Options) error {
+	var jobs []copyJob
+	var dirs []string
+	if err := collectJobs(srcFS, src, dstFS, dst, &jobs, &dirs); err != nil {
+		return err
+	}
+
+	progress := Progress{TotalFiles: len(jobs)}
+	for _, j := range jobs {
+		progress.Total += j.size
+	}
+	report := func() {
+		if opts.Progress != nil {
+			opts.Progress(progress)
+		}
+	}
+	report()
+
+	for _, dir := range dirs {
+		if err := dstFS.MkdirAll(dir); err != nil {
+			return fmt.Errorf(i18n.T(i18n.TransferFailed), dir, err)
+		}
+	}
+
+	for _, j := range jobs {
+		progress.File = j.src
+		report()
+		if err := copyFile(ctx, srcFS, dstFS, j, opts.Resume, func(n int64) {
+			progress.Done += n
+			report()
+		}); err != nil {
+			return fmt.Errorf(i18n.T(i18n.TransferFailed), j.src, err)
+		}
+		if opts.Verify {
+			if err := verifyFile(ctx, srcFS, j.src, dstFS, j.dst); err != nil {
+				return fmt.Errorf(i18n.T(i18n.TransferFailed), j.src, err)
+			}
+		}
+		progress.Files++
+		report()
+	}
+	return nil
+}
+
+// collectJobs 遍历源路径，生成文件复制任务和需要创建的目录
+func collectJobs(srcFS fileSystem, src string, dstFS fileSystem, dst string, jobs *[]copyJob, dirs *[]string) error {
+	info, err := srcFS.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		*jobs = append(*jobs, copyJob{src: src, dst: dst, size: info.Size()})
+		return nil
+	}
+
+	*dirs = append(*dirs, dst)
+	children, err := srcFS.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err := collectJobs(srcFS, srcFS.Join(src, child.Name()), dstFS, dstFS.Join(dst, child.Name()), jobs, dirs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile 复制单个文件；resume 为 true 且目标文件是源文件的开头部分时从目标文件已有的长度继续
+func copyFile(ctx context.Context, srcFS, dstFS fileSystem, j copyJob, resume bool, progress func(int64)) error {
+	var offset int64
+	if resume {
+		if info, err := dstFS.Stat(j.dst); err == nil && !info.IsDir() && info.Size() > 0 && info.Size() <= j.size {
+			// 同名的旧文件或无关文件不能续传，比较已有部分与源文件开头的 SHA-256
+			srcSum, err := prefixChecksum(ctx, srcFS, j.src, info.Size())
+			if err != nil {
+				return err
+			}
+			dstSum, err := prefixChecksum(ctx, dstFS, j.dst, info.Size())
+			if err != nil {
+				return err
+			}
+			if srcSum == dstSum {
+				offset = info.Size()
+			}
+		}
+	}
+
+	in, err := srcFS.Open(j.src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := dstFS.OpenWrite(j.dst, offset == 0)
+	if err != nil {
+		return err
+	}
+
+	if offset > 0 {
+		if _, err := in.Seek(offset, io.SeekStart); err != nil {
+			out.Close()
+			return err
+		}
+		if _, err := out.Seek(offset, io.SeekStart); err != nil {
+			out.Close()
+			return err
+		}
+		progress(offset)
+	}
+
+	_, err = io.Copy(out, &progressReader{ctx: ctx, r: in, progress: progress})
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// progressReader 统计读取字节数并响应取消
+type progressReader struct {
+	ctx      context.Context
+	r        io.Reader
+	progress func(int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	if err := p.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.progress(int64(n))
+	}
+	return n, err
+}
+
+// verifyFile 比较源文件与目标文件的 SHA-256
+func verifyFile(ctx context.Context, srcFS fileSystem, src string, dstFS fileSystem, dst string) error {
+	srcSum, err := fileChecksum(ctx, srcFS, src)
+	if err != nil {
+		return err
+	}
+	dstSum, err := fileChecksum(ctx, dstFS, dst)
+	if err != nil {
+		return err
+	}
+	if srcSum != dstSum {
+		return fmt.Errorf("%s", i18n.TWithArgs(i18n.ChecksumMismatch, srcSum, dstSum))
+	}
+	return nil
+}
+
+// fileChecksum 计算文件的 SHA-256（远程文件通过 SFTP 读取）
+func fileChecksum(ctx context.Context, fs fileSystem, name string) (string, error) {
+	return prefixChecksum(ctx, fs, name, -1)
+}
+
+// prefixChecksum 计算文件前 n 字节的 SHA-256，n 为负数时计算整个文件
+func prefixChecksum(ctx context.Context, fs fileSystem, name string, n int64) (string, error) {
+	f, err := fs.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if n >= 0 {
+		r = io.LimitReader(f, n)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, &progressReader{ctx: ctx, r: r, progress: func(int64) {}}); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,170 @@
+package transfer
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/sftp"
+)
+
+// newTestSession 创建一个连接到进程内 SFTP 服务端（直接访问本地文件系统）的会话
+func newTestSession(t *testing.T) *Session {
+	t.Helper()
+	serverRead, clientWrite := io.Pipe()
+	clientRead, serverWrite := io.Pipe()
+
+	server, err := sftp.NewServer(struct {
+		io.Reader
+		io.WriteCloser
+	}{serverRead, serverWrite})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve()
+
+	client, err := sftp.NewClientPipe(clientRead, clientWrite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Session{client: client, remote: &remoteFS{client: client}}
+	t.Cleanup(func() {
+		server.Close()
+		s.Close()
+	})
+	return s
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestUploadDirectory(t *testing.T) {
+	s := newTestSession(t)
+	src, dst := t.TempDir(), t.TempDir()
+	writeTestFile(t, filepath.Join(src, "app", "a.txt"), "hello")
+	writeTestFile(t, filepath.Join(src, "app", "sub", "b.txt"), "world!")
+
+	var last Progress
+	err := s.Upload(context.Background(), filepath.Join(src, "app"), dst, Options{
+		Progress: func(p Progress) { last = p },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readTestFile(t, filepath.Join(dst, "app", "a.txt")); got != "hello" {
+		t.Errorf("a.txt = %q", got)
+	}
+	if got := readTestFile(t, filepath.Join(dst, "app", "sub", "b.txt")); got != "world!" {
+		t.Errorf("b.txt = %q", got)
+	}
+	if last.Files != 2 || last.TotalFiles != 2 || last.Done != 11 || last.Total != 11 {
+		t.Errorf("final progress = %+v", last)
+	}
+}
+
+func TestDownloadResume(t *testing.T) {
+	s := newTestSession(t)
+	src, dst := t.TempDir(), t.TempDir()
+	writeTestFile(t, filepath.Join(src, "log.txt"), "0123456789")
+	// 模拟上次中断时已经下载的部分
+	writeTestFile(t, filepath.Join(dst, "log.txt"), "01234")
+
+	var last Progress
+	err := s.Download(context.Background(), filepath.Join(src, "log.txt"), dst, Options{
+		Resume:   true,
+		Progress: func(p Progress) { last = p },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, filepath.Join(dst, "log.txt")); got != "0123456789" {
+		t.Errorf("resumed file = %q", got)
+	}
+	if last.Done != 10 {
+		t.Errorf("progress done = %d, want 10", last.Done)
+	}
+
+	// 同名但内容不同的文件不续传，重新传输
+	writeTestFile(t, filepath.Join(dst, "log.txt"), "abcde")
+	if err := s.Download(context.Background(), filepath.Join(src, "log.txt"), dst, Options{Resume: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, filepath.Join(dst, "log.txt")); got != "0123456789" {
+		t.Errorf("file with a different prefix = %q, want it transferred again", got)
+	}
+	writeTestFile(t, filepath.Join(dst, "log.txt"), "9876543210")
+	if err := s.Download(context.Background(), filepath.Join(src, "log.txt"), dst, Options{Resume: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, filepath.Join(dst, "log.txt")); got != "0123456789" {
+		t.Errorf("same-size file with different content = %q, want it transferred again", got)
+	}
+
+	// 不续传时覆盖目标文件
+	writeTestFile(t, filepath.Join(dst, "log.txt"), "garbage-longer-than-source")
+	if err := s.Download(context.Background(), filepath.Join(src, "log.txt"), dst, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, filepath.Join(dst, "log.txt")); got != "0123456789" {
+		t.Errorf("overwritten file = %q", got)
+	}
+}
+
+func TestTransferCancelled(t *testing.T) {
+	s := newTestSession(t)
+	src, dst := t.TempDir(), t.TempDir()
+	writeTestFile(t, filepath.Join(src, "a.txt"), "data")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.Upload(ctx, filepath.Join(src, "a.txt"), dst, Options{}); err == nil {
+		t.Fatal("expected error for cancelled transfer")
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	s := newTestSession(t)
+	src, dst := t.TempDir(), t.TempDir()
+	writeTestFile(t, filepath.Join(src, "a.txt"), "0123456789")
+	if err := s.Upload(context.Background(), filepath.Join(src, "a.txt"), dst, Options{Verify: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 目标中已有内容不同但长度相同的文件时，续传不会跳过，重新传输后校验通过
+	writeTestFile(t, filepath.Join(dst, "a.txt"), "abcdefghij")
+	if err := s.Upload(context.Background(), filepath.Join(src, "a.txt"), dst, Options{Resume: true, Verify: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, filepath.Join(dst, "a.txt")); got != "0123456789" {
+		t.Errorf("uploaded file = %q", got)
+	}
+}
+
+func TestProgressPercent(t *testing.T) {
+	// 传输中源文件变大时已传输字节数会超过总数
+	if got := (Progress{Done: 150, Total: 100}).Percent(); got != 1 {
+		t.Errorf("Percent() = %v, want 1", got)
+	}
+	if got := (Progress{Done: 50, Total: 100}).Percent(); got != 0.5 {
+		t.Errorf("Percent() = %v, want 0.5", got)
+	}
+}
@@ -0,0 +1,167 @@
+package transfer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/ssh"
+
+	"github.com/pkg/sftp"
+)
+
+// Entry 目录中的一个文件或子目录
+type Entry struct {
+	Name    string
+	Size    int64
+	Mode    os.FileMode
+	ModTime time.Time
+	IsDir   bool
+}
+
+// Session 一个 SFTP 会话，通过系统 ssh 的 sftp 子系统建立
+type Session struct {
+	Host   ssh.SSHHost
+	client *sftp.Client
+	cmd    *exec.Cmd
+	remote *remoteFS
+}
+
+// Open 打开到主机的 SFTP 会话
+func Open(host ssh.SSHHost) (*Session, error) {
+	cmd := ssh.SubsystemCommand(host, "sftp")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	// 密码提示由 ssh 直接写入终端，这里只收集错误输出用于报错
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf(i18n.T(i18n.SFTPConnectFailed), host.Host, err)
+	}
+
+	client, err := sftp.NewClientPipe(stdout, stdin)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%s", msg)
+		}
+		return nil, fmt.Errorf(i18n.T(i18n.SFTPConnectFailed), host.Host, err)
+	}
+
+	return &Session{Host: host, client: client, cmd: cmd, remote: &remoteFS{client: client}}, nil
+}
+
+// Close 关闭会话并等待 ssh 进程退出
+func (s *Session) Close() error {
+	err := s.client.Close()
+	if s.cmd != nil {
+		_ = s.cmd.Wait()
+	}
+	return err
+}
+
+// Getwd 返回远程当前目录（通常为用户主目录）
+func (s *Session) Getwd() (string, error) {
+	return s.client.Getwd()
+}
+
+// Stat 获取远程文件信息
+func (s *Session) Stat(name string) (os.FileInfo, error) {
+	return s.client.Stat(name)
+}
+
+// Glob 匹配远程路径
+func (s *Session) Glob(pattern string) ([]string, error) {
+	return s.client.Glob(pattern)
+}
+
+// ReadDir 列出远程目录，目录排在文件前面
+func (s *Session) ReadDir(dir string) ([]Entry, error) {
+	infos, err := s.client.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return toEntries(infos), nil
+}
+
+// ReadLocalDir 列出本地目录，目录排在文件前面
+func ReadLocalDir(dir string) ([]Entry, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]os.FileInfo, 0, len(dirEntries))
+	for _, e := range dirEntries {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return toEntries(infos), nil
+}
+
+// toEntries 转换并排序目录项
+func toEntries(infos []os.FileInfo) []Entry {
+	entries := make([]Entry, 0, len(infos))
+	for _, info := range infos {
+		entries = append(entries, Entry{
+			Name:    info.Name(),
+			Size:    info.Size(),
+			Mode:    info.Mode(),
+			ModTime: info.ModTime(),
+			IsDir:   info.IsDir(),
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].IsDir != entries[j].IsDir {
+			return entries[i].IsDir
+		}
+		return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
+	})
+	return entries
+}
+
+// RemoteParent 返回远程路径的上级目录
+func RemoteParent(dir string) string {
+	return path.Dir(dir)
+}
+
+// RemoteJoin 拼接远程路径
+func RemoteJoin(elem ...string) string {
+	return path.Join(elem...)
+}
+
+// LocalParent 返回本地路径的上级目录
+func LocalParent(dir string) string {
+	return filepath.Dir(dir)
+}
+
+// FormatSize 将字节数格式化为易读的大小
+func FormatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
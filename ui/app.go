@@ -5,8 +5,10 @@ import (
 	"strings"
 
 	"sshgo/i18n"
+	"sshgo/mux"
 	"sshgo/operations"
 	"sshgo/ssh"
+	"sshgo/state"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -31,6 +33,24 @@ const (
 	stateInputUsername
 	stateInputPort
 	stateInputConnectUsername
+	stateSelectBackup
+	stateConfirmRestoreBackup
+	stateInputRename
+	stateInputDuplicate
+	stateInputTags
+	stateHistory
+	stateBatchMenu
+	stateBatchInput
+	stateConfirmBatchDelete
+	stateBatchCheck
+	stateRunInput
+	stateRun
+	stateRunSave
+	stateRecordings
+	stateInputRemoteCommand
+	stateInputConnectRun
+	stateConfirmConflict
+	statePalette
 )
 
 // ActionType 操作类型（导出供外部使用）
@@ -38,15 +58,21 @@ type ActionType string
 
 const (
 	ActionConnect            ActionType = "connect"
+	ActionConnectNewWindow   ActionType = "connect_new_window"
+	ActionConnectRun         ActionType = "connect_run"
+	ActionSetRemoteCommand   ActionType = "set_remote_command"
 	ActionDetails            ActionType = "details"
 	ActionDeleteKey          ActionType = "delete_key"
 	ActionDeleteConfig       ActionType = "delete_config"
 	ActionModifyUser         ActionType = "modify_user"
 	ActionModifyPort         ActionType = "modify_port"
+	ActionRename             ActionType = "rename"
+	ActionDuplicate          ActionType = "duplicate"
+	ActionEditTags           ActionType = "edit_tags"
+	ActionHistory            ActionType = "history"
 	ActionNetworkDiagnostics ActionType = "network_diagnostics"
+	ActionRestoreBackup      ActionType = "restore_backup"
 	ActionBack               ActionType = "back"
-	ActionExit               ActionType = "exit"
-	ActionNone               ActionType = ""
 )
 
 // ============================================================================
@@ -59,9 +85,16 @@ type hostItem struct {
 	displayName string
 }
 
-func (i hostItem) Title() string       { return i.displayName }
-func (i hostItem) Description() string { return i.host.HostName }
-func (i hostItem) FilterValue() string { return i.displayName + " " + i.host.HostName }
+func (i hostItem) Title() string { return i.displayName }
+func (i hostItem) Description() string {
+	if tags := hostTagsLabel(i.host); tags != "" {
+		return strings.TrimSpace(i.host.HostName + " " + tags)
+	}
+	return i.host.HostName
+}
+func (i hostItem) FilterValue() string {
+	return strings.Join(append([]string{i.displayName, i.host.HostName, i.host.Group}, i.host.Tags...), " ")
+}
 
 // actionItem 操作列表项
 type actionItem struct {
@@ -73,100 +106,6 @@ func (i actionItem) Title() string       { return i.label }
 func (i actionItem) Description() string { return "" }
 func (i actionItem) FilterValue() string { return i.label }
 
-// ============================================================================
-// 键绑定
-// ============================================================================
-
-type keyMap struct {
-	Enter  key.Binding
-	Back   key.Binding
-	Quit   key.Binding
-	Search key.Binding
-	Yes    key.Binding
-	No     key.Binding
-}
-
-func getKeys() keyMap {
-	return keyMap{
-		Enter: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", i18n.T(i18n.KeySelect)),
-		),
-		Back: key.NewBinding(
-			key.WithKeys("esc"),
-			key.WithHelp("esc", i18n.T(i18n.KeyBack)),
-		),
-		Quit: key.NewBinding(
-			key.WithKeys("q", "ctrl+c"),
-			key.WithHelp("q", i18n.T(i18n.KeyQuit)),
-		),
-		Search: key.NewBinding(
-			key.WithKeys("/"),
-			key.WithHelp("/", i18n.T(i18n.KeySearch)),
-		),
-		Yes: key.NewBinding(
-			key.WithKeys("y", "Y"),
-			key.WithHelp("y", i18n.T(i18n.KeyConfirm)),
-		),
-		No: key.NewBinding(
-			key.WithKeys("n", "N"),
-			key.WithHelp("n", i18n.T(i18n.KeyCancel)),
-		),
-	}
-}
-
-// ============================================================================
-// 样式定义
-// ============================================================================
-
-var (
-	// 标题样式
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("170")).
-			MarginLeft(2)
-
-	// 状态信息样式
-	statusStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
-			MarginLeft(2)
-
-	// 错误样式
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
-			Bold(true).
-			MarginLeft(2)
-
-	// 成功样式
-	successStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("82")).
-			MarginLeft(2)
-
-	// 详情框样式
-	detailsBoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(1, 2).
-			MarginLeft(2).
-			MarginTop(1)
-
-	// 帮助样式
-	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
-			MarginLeft(2).
-			MarginTop(1)
-
-	// 警告样式
-	warningStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("208")).
-			Bold(true).
-			MarginLeft(2)
-
-	// 输入框样式
-	inputStyle = lipgloss.NewStyle().
-			MarginLeft(2)
-)
-
 // ============================================================================
 // 主应用模型
 // ============================================================================
@@ -181,13 +120,50 @@ type AppModel struct {
 	selectedHost ssh.SSHHost
 	configPath   string
 
+	// 已折叠的分组
+	collapsedGroups map[string]bool
+
+	// 使用记录与排序方式
+	usage    *state.Usage
+	sortMode sortMode
+
+	// 多选与批量操作
+	marked         map[string]bool
+	batchList      list.Model
+	batchDirective string
+	reachability   reachabilityState
+	run            runState
+
+	// 会话录制
+	recordings recordingsState
+
 	// 列表组件
-	hostList   list.Model
-	actionList list.Model
+	hostList    list.Model
+	hostIndex   *hostIndex
+	actionList  list.Model
+	backupList  list.Model
+	historyList list.Model
+
+	// 关闭历史界面后返回的状态
+	historyReturnState appState
+
+	// 用户名输入后执行的连接操作
+	connectAction ActionType
+
+	// 备份与 diff 预览
+	selectedBackup ssh.Backup
+	diffPreview    string
 
 	// 输入组件
 	textInput textinput.Model
 
+	// 按键绑定与按键帮助界面
+	keys        keyMap
+	showKeyHelp bool
+
+	// 上一次鼠标点击，用于识别双击
+	lastClick mouseClick
+
 	// 消息显示
 	message string
 	isError bool
@@ -196,57 +172,65 @@ type AppModel struct {
 	width  int
 	height int
 
+	// 子界面栈（网络诊断、SFTP 文件浏览）
+	screens []screen
+
+	// 右侧的主机预览
+	preview previewState
+
+	// 配置文件的最近状态与待确认的写入冲突
+	fileStamps map[string]ssh.FileStamp
+	conflict   *writeConflict
+
+	// 命令面板
+	palette paletteState
+
 	// 退出标志
 	quitting bool
-
-	// 返回给调用者的信息
-	resultAction ActionType
-	resultHost   *ssh.SSHHost
 }
 
 // NewAppModel 创建新的应用模型
 func NewAppModel(hosts []ssh.SSHHost, configPath string) AppModel {
-	// 创建主机列表项
-	hostItems := make([]list.Item, len(hosts))
-	for i, h := range hosts {
-		displayName := h.Host
-		if h.HostName != "" && h.HostName != h.Host {
-			displayName = fmt.Sprintf("%s (%s)", h.Host, h.HostName)
-		}
-		hostItems[i] = hostItem{host: h, displayName: displayName}
+	applyTheme()
+	keys := getKeys()
+
+	// 读取使用记录（失败时使用空记录，不影响列表显示）
+	usage, _ := state.LoadUsage()
+	mode := sortFrecency
+	if usage.SortMode != "" {
+		mode = parseSortMode(usage.SortMode)
 	}
 
 	// 配置主机列表
-	hostDelegate := list.NewDefaultDelegate()
+	hostDelegate := newListDelegate()
 	hostDelegate.ShowDescription = true
+	collapsedGroups := make(map[string]bool)
+	opts := hostItemOptions{collapsed: collapsedGroups, usage: usage}
+	hostItems := newHostItems(sortHosts(hosts, mode, usage), opts)
+	index := &hostIndex{}
+	index.set(hostItems)
 	hostList := list.New(hostItems, hostDelegate, 0, 0)
-	hostList.Title = i18n.T(i18n.SelectHostLabel)
+	hostList.Filter = index.filter
+	hostList.Title = fmt.Sprintf(i18n.T(i18n.HostListTitleSorted), i18n.T(i18n.SelectHostLabel), mode.label())
 	hostList.SetShowStatusBar(true)
 	hostList.SetFilteringEnabled(true)
 	hostList.SetShowHelp(true)
 	hostList.DisableQuitKeybindings()
-
-	// 创建操作列表项
-	actionItems := []list.Item{
-		actionItem{action: ActionConnect, label: i18n.T(i18n.ConnectAction)},
-		actionItem{action: ActionDetails, label: i18n.T(i18n.DetailsAction)},
-		actionItem{action: ActionDeleteKey, label: i18n.T(i18n.DeleteKeyAction)},
-		actionItem{action: ActionDeleteConfig, label: i18n.T(i18n.DeleteConfigAction)},
-		actionItem{action: ActionModifyUser, label: i18n.T(i18n.ModifyUserAction)},
-		actionItem{action: ActionModifyPort, label: i18n.T(i18n.ModifyPortAction)},
-		actionItem{action: ActionNetworkDiagnostics, label: i18n.T(i18n.NetworkDiagnosticsAction)},
-		actionItem{action: ActionBack, label: i18n.T(i18n.BackAction)},
-	}
+	keys.applyTo(&hostList)
+	styleList(&hostList)
+	hostList.AdditionalShortHelpKeys = keys.hostListHelp
 
 	// 配置操作列表
-	actionDelegate := list.NewDefaultDelegate()
+	actionDelegate := newListDelegate()
 	actionDelegate.ShowDescription = false
-	actionList := list.New(actionItems, actionDelegate, 0, 0)
+	actionList := list.New(newActionItems(), actionDelegate, 0, 0)
 	actionList.Title = i18n.T(i18n.SelectActionLabel)
 	actionList.SetShowStatusBar(false)
 	actionList.SetFilteringEnabled(false)
 	actionList.SetShowHelp(true)
 	actionList.DisableQuitKeybindings()
+	keys.applyTo(&actionList)
+	styleList(&actionList)
 
 	// 创建文本输入
 	ti := textinput.New()
@@ -254,20 +238,156 @@ func NewAppModel(hosts []ssh.SSHHost, configPath string) AppModel {
 	ti.Width = 40
 
 	return AppModel{
-		state:      stateHostList,
-		hosts:      hosts,
-		configPath: configPath,
-		hostList:   hostList,
-		actionList: actionList,
-		textInput:  ti,
-		width:      80,
-		height:     24,
+		state:           stateHostList,
+		hosts:           hosts,
+		collapsedGroups: collapsedGroups,
+		usage:           usage,
+		sortMode:        mode,
+		marked:          make(map[string]bool),
+		configPath:      configPath,
+		hostList:        hostList,
+		hostIndex:       index,
+		actionList:      actionList,
+		textInput:       ti,
+		keys:            keys,
+		width:           80,
+		height:          24,
+		preview:         newPreviewState(),
+		fileStamps:      ssh.StatFiles(ssh.WatchedFiles()),
+	}
+}
+
+// newActionItems 创建操作菜单的列表项
+func newActionItems() []list.Item {
+	actionItems := []list.Item{
+		actionItem{action: ActionConnect, label: i18n.T(i18n.ConnectAction)},
+		actionItem{action: ActionConnectRun, label: i18n.T(i18n.ConnectRunAction)},
+		actionItem{action: ActionConnectRecord, label: i18n.T(i18n.ConnectRecordAction)},
+		actionItem{action: ActionDetails, label: i18n.T(i18n.DetailsAction)},
+		actionItem{action: ActionDeleteKey, label: i18n.T(i18n.DeleteKeyAction)},
+		actionItem{action: ActionDeleteConfig, label: i18n.T(i18n.DeleteConfigAction)},
+		actionItem{action: ActionModifyUser, label: i18n.T(i18n.ModifyUserAction)},
+		actionItem{action: ActionModifyPort, label: i18n.T(i18n.ModifyPortAction)},
+		actionItem{action: ActionRename, label: i18n.T(i18n.RenameHostAction)},
+		actionItem{action: ActionDuplicate, label: i18n.T(i18n.DuplicateHostAction)},
+		actionItem{action: ActionEditTags, label: i18n.T(i18n.EditTagsAction)},
+		actionItem{action: ActionSetRemoteCommand, label: i18n.T(i18n.SetRemoteCommandAction)},
+		actionItem{action: ActionSFTP, label: i18n.T(i18n.SFTPAction)},
+		actionItem{action: ActionNetworkDiagnostics, label: i18n.T(i18n.NetworkDiagnosticsAction)},
+		actionItem{action: ActionHistory, label: i18n.T(i18n.HistoryAction)},
+		actionItem{action: ActionRecordings, label: i18n.T(i18n.RecordingsAction)},
+		actionItem{action: ActionRestoreBackup, label: i18n.T(i18n.RestoreBackupAction)},
+		actionItem{action: ActionBack, label: i18n.T(i18n.BackAction)},
+	}
+	// 在 tmux/screen 中时提供“在新窗口中连接”
+	if mux.Detect() != mux.None {
+		actionItems = append(actionItems[:1], append([]list.Item{
+			actionItem{action: ActionConnectNewWindow, label: i18n.T(i18n.ConnectNewWindowAction)},
+		}, actionItems[1:]...)...)
+	}
+	return actionItems
+}
+
+// reloadHosts 重新解析配置文件并刷新主机列表
+func (m *AppModel) reloadHosts() tea.Cmd {
+	hosts, err := ssh.ParseSSHConfig(m.configPath)
+	if err != nil {
+		m.message = err.Error()
+		m.isError = true
+		return nil
+	}
+	m.hosts = hosts
+	// 配置变化后重新加载预览
+	m.preview.configs = make(map[string]previewConfig)
+	m.preview.alias = ""
+	return m.refreshHostItems()
+}
+
+// refreshHostItems 按当前排序方式、收藏和分组折叠状态重建主机列表项
+func (m *AppModel) refreshHostItems() tea.Cmd {
+	m.hostList.Title = fmt.Sprintf(i18n.T(i18n.HostListTitleSorted), i18n.T(i18n.SelectHostLabel), m.sortMode.label())
+	if len(m.marked) > 0 {
+		m.hostList.Title += " · " + fmt.Sprintf(i18n.T(i18n.SelectedCount), len(m.marked))
+	}
+	opts := hostItemOptions{
+		collapsed: m.collapsedGroups,
+		usage:     m.usage,
+		marked:    m.marked,
+		expandAll: m.hostList.FilterState() != list.Unfiltered,
+	}
+	items := newHostItems(sortHosts(m.hosts, m.sortMode, m.usage), opts)
+	m.hostIndex.set(items)
+	return m.hostList.SetItems(items)
+}
+
+// toggleGroup 展开/折叠分组，光标停留在分组标题上
+func (m *AppModel) toggleGroup(item groupItem) tea.Cmd {
+	m.collapsedGroups[item.name] = !item.collapsed
+	index := m.hostList.Index()
+	cmd := m.refreshHostItems()
+	m.hostList.Select(index)
+	return cmd
+}
+
+// toggleFavorite 收藏或取消收藏主机，光标停留在该主机上
+// 基于文件中的最新记录修改，同时载入其他地方记录的连接
+func (m *AppModel) toggleFavorite(alias string) tea.Cmd {
+	usage, favorite, err := state.ToggleFavorite(alias)
+	switch {
+	case err != nil:
+		m.message = fmt.Sprintf(i18n.T(i18n.SaveUsageFailed), err)
+		m.isError = true
+		return nil
+	case favorite:
+		m.message = fmt.Sprintf(i18n.T(i18n.FavoriteAdded), alias)
+	default:
+		m.message = fmt.Sprintf(i18n.T(i18n.FavoriteRemoved), alias)
+	}
+	m.isError = false
+	m.usage = usage
+	cmd := m.refreshHostItems()
+	m.selectHost(alias)
+	return cmd
+}
+
+// reloadUsage 重新读取连接后更新的使用记录并刷新主机列表，光标停留在原来的主机上
+func (m *AppModel) reloadUsage() tea.Cmd {
+	usage, err := state.LoadUsage()
+	if err != nil {
+		return nil
+	}
+	m.usage = usage
+	selected, ok := m.selectedHostItem()
+	cmd := m.refreshHostItems()
+	if ok && m.hostList.FilterState() == list.Unfiltered {
+		m.selectHost(selected.Host)
+	}
+	return cmd
+}
+
+// selectHost 将主机列表光标移动到指定主机；主机在折叠的分组中时先展开该分组
+func (m *AppModel) selectHost(alias string) {
+	if m.hostList.FilterState() == list.Unfiltered {
+		for _, h := range m.hosts {
+			if h.Host == alias && m.collapsedGroups[h.Group] {
+				m.collapsedGroups[h.Group] = false
+				// 未过滤时 SetItems 不返回命令
+				m.refreshHostItems()
+				break
+			}
+		}
+	}
+	for i, item := range m.hostList.VisibleItems() {
+		if h, ok := item.(hostItem); ok && h.host.Host == alias {
+			m.hostList.Select(i)
+			return
+		}
 	}
 }
 
 // Init 初始化
 func (m AppModel) Init() tea.Cmd {
-	return nil
+	return watchConfig()
 }
 
 // Update 更新
@@ -278,20 +398,90 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		h := max(msg.Height, 5)
-		m.hostList.SetSize(msg.Width-4, h)
+		m.hostList.SetSize(m.hostListWidth(), h)
 		m.actionList.SetSize(msg.Width-4, h)
-		return m, nil
+		if m.state == stateSelectBackup {
+			m.backupList.SetSize(msg.Width-4, h)
+		}
+		if m.state == stateHistory {
+			m.historyList.SetSize(msg.Width-4, h)
+		}
+		if m.state == stateBatchMenu {
+			m.batchList.SetSize(msg.Width-4, h)
+		}
+		if m.state == stateRecordings {
+			m.recordings.list.SetSize(msg.Width-4, h)
+		}
+		if len(m.screens) > 0 {
+			return m.updateScreen(msg)
+		}
+		cmd := m.updatePreview()
+		return m, cmd
+
+	case screenClosedMsg:
+		return m.popScreen(), nil
+
+	case connectDoneMsg:
+		return m.finishConnect(msg)
+
+	case configStampsMsg:
+		return withPreview(m.handleConfigStamps(msg))
+
+	case previewTickMsg, previewConfigMsg, previewReachMsg:
+		return m.handlePreviewMsg(msg)
+
+	case sftpOpenedMsg:
+		if msg.err != nil {
+			m.message = msg.err.Error()
+			m.isError = true
+			return m, nil
+		}
+		return m.pushScreen(NewSFTPModel(msg.session))
 
 	case tea.KeyMsg:
-		// 全局退出（仅在非输入状态）
+		// 全局退出
 		if msg.String() == "ctrl+c" {
+			m.run.stop()
+			m.releaseScreens()
 			m.quitting = true
-			m.resultAction = ActionExit
 			return m, tea.Quit
 		}
+		if len(m.screens) > 0 {
+			break
+		}
+		// 帮助界面中按任意键关闭
+		if m.showKeyHelp {
+			m.showKeyHelp = false
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Help) && m.keyHelpAvailable() {
+			m.showKeyHelp = true
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Palette) && m.paletteAvailable() {
+			return m.openPalette()
+		}
+
+	case tea.MouseMsg:
+		// 帮助界面中点击关闭
+		if len(m.screens) == 0 && m.showKeyHelp {
+			if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+				m.showKeyHelp = false
+			}
+			return m, nil
+		}
 	}
 
-	// 根据状态分发处理
+	// 子界面打开时由子界面处理
+	if len(m.screens) > 0 {
+		return m.updateScreen(msg)
+	}
+
+	return withPreview(m.updateState(msg))
+}
+
+// updateState 根据状态分发处理
+func (m AppModel) updateState(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.state {
 	case stateHostList:
 		return m.updateHostList(msg)
@@ -309,61 +499,155 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateInputPort(msg)
 	case stateInputConnectUsername:
 		return m.updateInputConnectUsername(msg)
+	case stateInputRename, stateInputDuplicate:
+		return m.updateInputAlias(msg)
+	case stateInputTags:
+		return m.updateInputTags(msg)
+	case stateInputRemoteCommand, stateInputConnectRun:
+		return m.updateInputRemoteCommand(msg)
+	case stateHistory:
+		return m.updateHistory(msg)
+	case stateBatchMenu:
+		return m.updateBatchMenu(msg)
+	case stateBatchInput:
+		return m.updateBatchInput(msg)
+	case stateConfirmBatchDelete:
+		return m.updateConfirmBatchDelete(msg)
+	case stateBatchCheck:
+		return m.updateBatchCheck(msg)
+	case stateRunInput:
+		return m.updateRunInput(msg)
+	case stateRun:
+		return m.updateRun(msg)
+	case stateRunSave:
+		return m.updateRunSave(msg)
+	case stateRecordings:
+		return m.updateRecordings(msg)
+	case stateSelectBackup:
+		return m.updateSelectBackup(msg)
+	case stateConfirmRestoreBackup:
+		return m.updateConfirmRestoreBackup(msg)
+	case stateConfirmConflict:
+		return m.updateConfirmConflict(msg)
+	case statePalette:
+		return m.updatePalette(msg)
 	}
 
 	return m, nil
 }
 
+// keyHelpAvailable 是否可以打开按键帮助：文本输入和列表过滤时帮助键作为普通字符输入
+func (m AppModel) keyHelpAvailable() bool {
+	switch m.state {
+	case stateHostList:
+		return m.hostList.FilterState() != list.Filtering
+	case stateHistory:
+		return m.historyList.FilterState() != list.Filtering
+	case stateRecordings:
+		return m.recordings.list.FilterState() != list.Filtering
+	case stateActionMenu, stateSelectBackup, stateBatchMenu, stateBatchCheck, stateRun,
+		stateConfirmDeleteKey, stateConfirmDeleteConfig, stateConfirmRestoreBackup, stateConfirmBatchDelete, stateConfirmConflict:
+		return true
+	}
+	return false
+}
+
 // ============================================================================
 // 状态更新函数
 // ============================================================================
 
 // updateHostList 更新主机列表状态
 func (m AppModel) updateHostList(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "esc":
-			// 如果列表正在过滤中，不退出
-			if m.hostList.FilterState() == list.Filtering {
+	if msg, ok := msg.(tea.MouseMsg); ok {
+		return m.updateHostListMouse(msg)
+	}
+	// 列表正在过滤中时按键交给列表处理
+	if msg, ok := msg.(tea.KeyMsg); ok && m.hostList.FilterState() != list.Filtering {
+		switch {
+		case key.Matches(msg, m.keys.Quit, m.keys.Back):
+			back := key.Matches(msg, m.keys.Back)
+			// 已应用过滤时返回键先清除过滤
+			if back && m.hostList.FilterState() == list.FilterApplied && len(m.marked) == 0 {
 				break
 			}
+			// 有多选时返回键先清除选择
+			if back && len(m.marked) > 0 && m.hostList.FilterState() == list.Unfiltered {
+				cmd := m.clearMarks()
+				return m, cmd
+			}
 			m.quitting = true
-			m.resultAction = ActionExit
 			return m, tea.Quit
-		case "enter":
-			// 如果列表正在过滤中，让列表处理
-			if m.hostList.FilterState() == list.Filtering {
-				break
+		case key.Matches(msg, m.keys.History):
+			return m.openHistory("")
+		case key.Matches(msg, m.keys.Recordings):
+			return m.openRecordings("")
+		case key.Matches(msg, m.keys.Mark):
+			cmd := m.toggleMark()
+			return m, cmd
+		case key.Matches(msg, m.keys.MarkAll):
+			cmd := m.toggleMarkAll()
+			return m, cmd
+		case key.Matches(msg, m.keys.Undo, m.keys.Redo):
+			return m.undoRedo(key.Matches(msg, m.keys.Undo))
+		case key.Matches(msg, m.keys.Enter):
+			// 有多选时进入批量操作菜单
+			if len(m.marked) > 0 {
+				return m.openBatchMenu()
 			}
-			if item, ok := m.hostList.SelectedItem().(hostItem); ok {
+			switch item := m.hostList.SelectedItem().(type) {
+			case hostItem:
 				m.selectedHost = item.host
 				m.state = stateActionMenu
 				m.message = ""
 				return m, nil
+			case groupItem:
+				cmd := m.toggleGroup(item)
+				return m, cmd
 			}
+		case key.Matches(msg, m.keys.Sort):
+			cmd := m.cycleSort()
+			return m, cmd
+		case key.Matches(msg, m.keys.Pin):
+			item, ok := m.hostList.SelectedItem().(hostItem)
+			if !ok {
+				break
+			}
+			cmd := m.toggleFavorite(item.host.Host)
+			return m, cmd
 		}
 	}
 
+	filterState := m.hostList.FilterState()
 	var cmd tea.Cmd
 	m.hostList, cmd = m.hostList.Update(msg)
+	// 开始或清除过滤时重建列表项：过滤时展开所有分组，使折叠分组中的主机也能被找到
+	if (filterState == list.Unfiltered) != (m.hostList.FilterState() == list.Unfiltered) {
+		cmd = tea.Batch(cmd, m.refreshHostItems())
+	}
 	return m, cmd
 }
 
 // updateActionMenu 更新操作菜单状态
 func (m AppModel) updateActionMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		// 点击菜单项直接执行
+		if index, _ := handleListMouse(&m.actionList, menuItemHeight, &m.lastClick, msg); index >= 0 {
+			if item, ok := m.actionList.SelectedItem().(actionItem); ok {
+				return m.handleAction(item.action)
+			}
+		}
+		return m, nil
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q":
+		switch {
+		case key.Matches(msg, m.keys.Quit):
 			m.quitting = true
-			m.resultAction = ActionExit
 			return m, tea.Quit
-		case "esc":
+		case key.Matches(msg, m.keys.Back):
 			m.state = stateHostList
 			m.message = ""
 			return m, nil
-		case "enter":
+		case key.Matches(msg, m.keys.Enter):
 			if item, ok := m.actionList.SelectedItem().(actionItem); ok {
 				return m.handleAction(item.action)
 			}
@@ -388,8 +672,8 @@ func (m AppModel) updateHostDetails(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m AppModel) updateConfirmDeleteKey(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "y", "Y":
+		switch {
+		case key.Matches(msg, m.keys.Yes):
 			// 执行删除
 			err := operations.DeleteKeyFile(m.selectedHost)
 			if err != nil {
@@ -401,7 +685,7 @@ func (m AppModel) updateConfirmDeleteKey(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.state = stateActionMenu
 			return m, nil
-		case "n", "N", "esc":
+		case key.Matches(msg, m.keys.No, m.keys.Back):
 			m.message = i18n.T(i18n.CancelOperation)
 			m.isError = false
 			m.state = stateActionMenu
@@ -415,25 +699,31 @@ func (m AppModel) updateConfirmDeleteKey(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m AppModel) updateConfirmDeleteConfig(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "y", "Y":
+		switch {
+		case key.Matches(msg, m.keys.Yes):
 			// 执行删除
-			err := operations.DeleteHostConfig(m.selectedHost)
+			warning, err := operations.DeleteHostConfig(m.selectedHost)
+			if m.checkConflict(err, msg) {
+				return m, nil
+			}
 			if err != nil {
 				m.message = err.Error()
 				m.isError = true
 			} else {
 				m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullyDeletedConfig), m.selectedHost.Host)
 				m.isError = false
-				// 需要刷新主机列表
-				m.resultAction = ActionDeleteConfig
-				m.resultHost = &m.selectedHost
-				m.quitting = true
-				return m, tea.Quit
+				if warning != "" {
+					m.message += "\n" + warning
+					m.isError = true
+				}
+				// 刷新主机列表
+				m.state = stateHostList
+				cmd := m.reloadHosts()
+				return m, cmd
 			}
 			m.state = stateActionMenu
 			return m, nil
-		case "n", "N", "esc":
+		case key.Matches(msg, m.keys.No, m.keys.Back):
 			m.message = i18n.T(i18n.CancelOperation)
 			m.isError = false
 			m.state = stateActionMenu
@@ -447,13 +737,16 @@ func (m AppModel) updateConfirmDeleteConfig(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m AppModel) updateInputUsername(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
+		switch {
+		case key.Matches(msg, m.keys.Enter):
 			username := m.textInput.Value()
 			if username == "" {
-				username = i18n.T(i18n.DefaultUsername)
+				username = ssh.DefaultUsername()
 			}
 			err := operations.ModifyUser(m.selectedHost, username)
+			if m.checkConflict(err, msg) {
+				return m, nil
+			}
 			if err != nil {
 				m.message = err.Error()
 				m.isError = true
@@ -463,7 +756,7 @@ func (m AppModel) updateInputUsername(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.state = stateActionMenu
 			return m, nil
-		case "esc":
+		case key.Matches(msg, m.keys.Back):
 			m.message = i18n.T(i18n.CancelOperation)
 			m.isError = false
 			m.state = stateActionMenu
@@ -480,13 +773,16 @@ func (m AppModel) updateInputUsername(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m AppModel) updateInputPort(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
+		switch {
+		case key.Matches(msg, m.keys.Enter):
 			port := m.textInput.Value()
 			if port == "" {
 				port = "22"
 			}
 			err := operations.ModifyPort(m.selectedHost, port)
+			if m.checkConflict(err, msg) {
+				return m, nil
+			}
 			if err != nil {
 				m.message = err.Error()
 				m.isError = true
@@ -496,7 +792,132 @@ func (m AppModel) updateInputPort(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.state = stateActionMenu
 			return m, nil
-		case "esc":
+		case key.Matches(msg, m.keys.Back):
+			m.message = i18n.T(i18n.CancelOperation)
+			m.isError = false
+			m.state = stateActionMenu
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// updateInputAlias 更新重命名/复制主机的别名输入状态
+func (m AppModel) updateInputAlias(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Enter):
+			alias := strings.Join(strings.Fields(m.textInput.Value()), " ")
+			var err error
+			if m.state == stateInputRename {
+				err = operations.RenameHost(m.selectedHost, alias)
+			} else {
+				err = operations.DuplicateHost(m.selectedHost, alias)
+			}
+			if m.checkConflict(err, msg) {
+				return m, nil
+			}
+			if err != nil {
+				// 保持在输入状态，方便修改后重试
+				m.message = err.Error()
+				m.isError = true
+				return m, nil
+			}
+			if m.state == stateInputRename {
+				m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullyRenamedHost), m.selectedHost.Host, alias)
+			} else {
+				m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullyDuplicatedHost), m.selectedHost.Host, alias)
+			}
+			m.isError = false
+			m.state = stateHostList
+			cmd := m.reloadHosts()
+			return m, cmd
+		case key.Matches(msg, m.keys.Back):
+			m.message = i18n.T(i18n.CancelOperation)
+			m.isError = false
+			m.state = stateActionMenu
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// updateInputTags 更新标签/分组输入状态
+func (m AppModel) updateInputTags(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Enter):
+			meta, err := ssh.ParseHostMeta(m.textInput.Value())
+			if err == nil {
+				err = operations.SetHostMeta(m.selectedHost, meta)
+			}
+			if m.checkConflict(err, msg) {
+				return m, nil
+			}
+			if err != nil {
+				m.message = err.Error()
+				m.isError = true
+				return m, nil
+			}
+			m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullySetHostMeta), m.selectedHost.Host)
+			m.isError = false
+			m.selectedHost.Tags = meta.Tags
+			m.selectedHost.Group = meta.Group
+			m.state = stateActionMenu
+			cmd := m.reloadHosts()
+			return m, cmd
+		case key.Matches(msg, m.keys.Back):
+			m.message = i18n.T(i18n.CancelOperation)
+			m.isError = false
+			m.state = stateActionMenu
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// updateInputRemoteCommand 更新远程命令输入状态：保存为主机配置，或仅用于本次连接
+func (m AppModel) updateInputRemoteCommand(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Enter):
+			command := strings.TrimSpace(m.textInput.Value())
+			if m.state == stateInputConnectRun {
+				m.selectedHost.RemoteCommand = command
+				return m.handleAction(ActionConnect)
+			}
+			if err := operations.SetRemoteCommand(m.selectedHost, command); err != nil {
+				if m.checkConflict(err, msg) {
+					return m, nil
+				}
+				m.message = err.Error()
+				m.isError = true
+				return m, nil
+			}
+			if command == "" {
+				m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullyClearedRemoteCommand), m.selectedHost.Host)
+				m.selectedHost.RequestTTY = ""
+			} else {
+				m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullySetRemoteCommand), m.selectedHost.Host, command)
+			}
+			m.isError = false
+			m.selectedHost.RemoteCommand = command
+			m.state = stateActionMenu
+			cmd := m.reloadHosts()
+			return m, cmd
+		case key.Matches(msg, m.keys.Back):
 			m.message = i18n.T(i18n.CancelOperation)
 			m.isError = false
 			m.state = stateActionMenu
@@ -513,22 +934,29 @@ func (m AppModel) updateInputPort(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m AppModel) updateInputConnectUsername(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter":
+		switch {
+		case key.Matches(msg, m.keys.Enter):
 			username := m.textInput.Value()
 			if username == "" {
-				username = i18n.T(i18n.DefaultUsername)
+				username = ssh.DefaultUsername()
 			}
 			// 设置用户名并准备连接
 			m.selectedHost.User = username
-			// 保存用户名
-			_ = ssh.SaveUserToConfig(m.selectedHost.Host, username)
-			// 返回连接操作
-			m.resultAction = ActionConnect
-			m.resultHost = &m.selectedHost
-			m.quitting = true
-			return m, tea.Quit
-		case "esc":
+			// 保存用户名；配置文件冲突时先确认合并，其他错误不影响本次连接
+			err := operations.ModifyUser(m.selectedHost, username)
+			if m.checkConflict(err, msg) {
+				return m, nil
+			}
+			// 普通连接或录制连接
+			model, cmd := m.connect(m.selectedHost, m.connectAction == ActionConnectRecord)
+			if app, ok := model.(AppModel); ok && err != nil && !app.isError {
+				// connect 会清空提示，保存失败的提示在之后设置，连接结束后仍可看到
+				app.message = err.Error()
+				app.isError = true
+				model = app
+			}
+			return model, cmd
+		case key.Matches(msg, m.keys.Back):
 			m.message = i18n.T(i18n.CancelOperation)
 			m.isError = false
 			m.state = stateActionMenu
@@ -545,26 +973,65 @@ func (m AppModel) updateInputConnectUsername(msg tea.Msg) (tea.Model, tea.Cmd) {
 // 操作处理
 // ============================================================================
 
+// undoRedo 撤销或重做最近一次配置修改，并刷新主机列表
+func (m AppModel) undoRedo(undo bool) (tea.Model, tea.Cmd) {
+	var entry *operations.JournalEntry
+	var err error
+	if undo {
+		entry, err = operations.Undo()
+	} else {
+		entry, err = operations.Redo()
+	}
+	if entry == nil {
+		m.message = err.Error()
+		m.isError = true
+		return m, nil
+	}
+
+	if undo {
+		m.message = fmt.Sprintf(i18n.T(i18n.UndoneChange), entry.Description)
+	} else {
+		m.message = fmt.Sprintf(i18n.T(i18n.RedoneChange), entry.Description)
+	}
+	m.isError = false
+	if err != nil {
+		m.message = err.Error()
+		m.isError = true
+	}
+	cmd := m.reloadHosts()
+	return m, cmd
+}
+
 // handleAction 处理操作
 func (m AppModel) handleAction(action ActionType) (tea.Model, tea.Cmd) {
 	m.message = ""
 	m.isError = false
 
 	switch action {
-	case ActionConnect:
+	case ActionConnect, ActionConnectRecord:
+		m.connectAction = action
 		// 检查是否需要用户名
 		if ssh.NeedsUsername(m.selectedHost) {
 			m.textInput.SetValue("")
-			m.textInput.Placeholder = i18n.T(i18n.DefaultUsername)
+			m.textInput.Placeholder = ssh.DefaultUsername()
 			m.textInput.Focus()
 			m.state = stateInputConnectUsername
 			return m, textinput.Blink
 		}
 		// 直接连接
-		m.resultAction = ActionConnect
-		m.resultHost = &m.selectedHost
-		m.quitting = true
-		return m, tea.Quit
+		return m.connect(m.selectedHost, action == ActionConnectRecord)
+
+	case ActionConnectNewWindow:
+		host := m.selectedHost
+		if err := operations.ConnectInNewWindow(host); err != nil {
+			m.message = err.Error()
+			m.isError = true
+			return m, nil
+		}
+		m.message = fmt.Sprintf(i18n.T(i18n.OpenedInNewWindow), host.Host)
+		m.state = stateHostList
+		cmd := m.reloadUsage()
+		return m, cmd
 
 	case ActionDetails:
 		m.state = stateHostDetails
@@ -580,12 +1047,19 @@ func (m AppModel) handleAction(action ActionType) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case ActionDeleteConfig:
+		diff, err := ssh.PreviewRemoveHost(m.selectedHost.Host)
+		if err != nil {
+			m.message = err.Error()
+			m.isError = true
+			return m, nil
+		}
+		m.diffPreview = diff
 		m.state = stateConfirmDeleteConfig
 		return m, nil
 
 	case ActionModifyUser:
 		m.textInput.SetValue(m.selectedHost.User)
-		m.textInput.Placeholder = i18n.T(i18n.DefaultUsername)
+		m.textInput.Placeholder = ssh.DefaultUsername()
 		m.textInput.Focus()
 		m.state = stateInputUsername
 		return m, textinput.Blink
@@ -601,11 +1075,64 @@ func (m AppModel) handleAction(action ActionType) (tea.Model, tea.Cmd) {
 		m.state = stateInputPort
 		return m, textinput.Blink
 
+	case ActionRename, ActionDuplicate:
+		m.textInput.SetValue(m.selectedHost.Host)
+		m.textInput.Placeholder = m.selectedHost.Host
+		m.textInput.Focus()
+		if action == ActionRename {
+			m.state = stateInputRename
+		} else {
+			m.state = stateInputDuplicate
+		}
+		return m, textinput.Blink
+
+	case ActionEditTags:
+		meta := ssh.HostMeta{Tags: m.selectedHost.Tags, Group: m.selectedHost.Group}
+		m.textInput.SetValue(meta.String())
+		m.textInput.Placeholder = "tags=prod,db group=eu"
+		m.textInput.Focus()
+		m.state = stateInputTags
+		return m, textinput.Blink
+
+	case ActionSetRemoteCommand, ActionConnectRun:
+		m.textInput.SetValue(m.selectedHost.RemoteCommand)
+		m.textInput.Placeholder = "sudo -i"
+		m.textInput.Focus()
+		if action == ActionSetRemoteCommand {
+			m.state = stateInputRemoteCommand
+		} else {
+			m.state = stateInputConnectRun
+		}
+		return m, textinput.Blink
+
+	case ActionHistory:
+		return m.openHistory(m.selectedHost.Host)
+
+	case ActionRecordings:
+		return m.openRecordings(m.selectedHost.Host)
+
 	case ActionNetworkDiagnostics:
-		m.resultAction = ActionNetworkDiagnostics
-		m.resultHost = &m.selectedHost
-		m.quitting = true
-		return m, tea.Quit
+		return m.pushScreen(NewNetworkModel(m.selectedHost))
+
+	case ActionSFTP:
+		return m.openSFTP(m.selectedHost)
+
+	case ActionRestoreBackup:
+		backupList, err := newBackupList(m.keys)
+		if err != nil {
+			m.message = err.Error()
+			m.isError = true
+			return m, nil
+		}
+		if len(backupList.Items()) == 0 {
+			m.message = i18n.T(i18n.NoBackupsFound)
+			m.isError = true
+			return m, nil
+		}
+		backupList.SetSize(m.width-4, max(m.height, 5))
+		m.backupList = backupList
+		m.state = stateSelectBackup
+		return m, nil
 
 	case ActionBack:
 		m.state = stateHostList
@@ -625,11 +1152,23 @@ func (m AppModel) View() string {
 		return ""
 	}
 
+	if len(m.screens) > 0 {
+		return m.screens[len(m.screens)-1].View()
+	}
+
+	if m.showKeyHelp {
+		return m.keys.renderKeyHelp()
+	}
+
 	var s strings.Builder
 
 	switch m.state {
 	case stateHostList:
-		s.WriteString(m.hostList.View())
+		if m.previewVisible() {
+			s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, m.hostList.View(), m.renderPreview()))
+		} else {
+			s.WriteString(m.hostList.View())
+		}
 
 	case stateActionMenu:
 		m.actionList.Title = fmt.Sprintf("%s: %s", i18n.T(i18n.SelectActionLabel), m.selectedHost.Host)
@@ -649,6 +1188,52 @@ func (m AppModel) View() string {
 
 	case stateInputPort:
 		s.WriteString(m.renderInputPort())
+
+	case stateInputRename, stateInputDuplicate:
+		s.WriteString(m.renderInputAlias())
+
+	case stateInputTags:
+		s.WriteString(m.renderInputTags())
+
+	case stateInputRemoteCommand, stateInputConnectRun:
+		s.WriteString(m.renderInputRemoteCommand())
+
+	case stateSelectBackup:
+		s.WriteString(m.backupList.View())
+
+	case stateHistory:
+		s.WriteString(m.historyList.View())
+
+	case stateBatchMenu:
+		s.WriteString(m.batchList.View())
+
+	case stateBatchInput:
+		s.WriteString(m.renderBatchInput())
+
+	case stateConfirmBatchDelete:
+		s.WriteString(m.renderConfirmBatchDelete())
+
+	case stateBatchCheck:
+		s.WriteString(m.renderBatchCheck())
+
+	case stateRunInput:
+		s.WriteString(m.renderRunInput())
+
+	case stateRun:
+		s.WriteString(m.renderRun())
+
+	case stateRunSave:
+		s.WriteString(m.renderRunSave())
+
+	case stateRecordings:
+		s.WriteString(m.recordings.list.View())
+
+	case stateConfirmRestoreBackup:
+		s.WriteString(m.renderConfirmRestoreBackup())
+	case stateConfirmConflict:
+		s.WriteString(m.renderConfirmConflict())
+	case statePalette:
+		s.WriteString(m.renderPalette())
 	}
 
 	// 显示消息
@@ -664,34 +1249,62 @@ func (m AppModel) View() string {
 	return s.String()
 }
 
-// renderHostDetails 渲染主机详情
-func (m AppModel) renderHostDetails() string {
+// hostDetails 主机的配置项、标签与上次连接时间，每项一行
+func hostDetails(host ssh.SSHHost, usage *state.Usage) string {
 	var details strings.Builder
 
-	details.WriteString(fmt.Sprintf(i18n.T(i18n.HostAlias), m.selectedHost.Host))
-	if m.selectedHost.HostName != "" {
+	details.WriteString(fmt.Sprintf(i18n.T(i18n.HostAlias), host.Host))
+	if host.HostName != "" {
 		details.WriteString("\n")
-		details.WriteString(fmt.Sprintf(i18n.T(i18n.HostName), m.selectedHost.HostName))
+		details.WriteString(fmt.Sprintf(i18n.T(i18n.HostName), host.HostName))
 	}
-	if m.selectedHost.User != "" {
+	if host.User != "" {
 		details.WriteString("\n")
-		details.WriteString(fmt.Sprintf(i18n.T(i18n.UserName), m.selectedHost.User))
+		details.WriteString(fmt.Sprintf(i18n.T(i18n.UserName), host.User))
 	}
-	port := m.selectedHost.Port
+	port := host.Port
 	if port == "" {
 		port = "22"
 	}
 	details.WriteString("\n")
 	fmt.Fprintf(&details, i18n.T(i18n.Port), port)
-	if m.selectedHost.KeyFile != "" {
+	if host.KeyFile != "" {
+		details.WriteString("\n")
+		details.WriteString(fmt.Sprintf(i18n.T(i18n.KeyFile), host.KeyFile))
+	}
+	if host.ProxyJump != "" {
+		details.WriteString("\n")
+		details.WriteString(fmt.Sprintf(i18n.T(i18n.ProxyJumpLabel), host.ProxyJump))
+	}
+	if host.RemoteCommand != "" {
 		details.WriteString("\n")
-		details.WriteString(fmt.Sprintf(i18n.T(i18n.KeyFile), m.selectedHost.KeyFile))
+		details.WriteString(fmt.Sprintf(i18n.T(i18n.RemoteCommandLabel), host.RemoteCommand))
 	}
+	if host.RequestTTY != "" {
+		details.WriteString("\n")
+		details.WriteString(fmt.Sprintf(i18n.T(i18n.RequestTTYLabel), host.RequestTTY))
+	}
+	if len(host.Tags) > 0 {
+		details.WriteString("\n")
+		details.WriteString(fmt.Sprintf(i18n.T(i18n.TagsLabel), strings.Join(host.Tags, ", ")))
+	}
+	if host.Group != "" {
+		details.WriteString("\n")
+		details.WriteString(fmt.Sprintf(i18n.T(i18n.GroupLabel), host.Group))
+	}
+	if u := usage.Get(host.Host); u.Count > 0 {
+		details.WriteString("\n")
+		details.WriteString(fmt.Sprintf(i18n.T(i18n.LastConnected), u.LastUsed.Format("2006-01-02 15:04"), u.Count))
+	}
+	return details.String()
+}
 
+// renderHostDetails 渲染主机详情
+func (m AppModel) renderHostDetails() string {
 	var s strings.Builder
 	s.WriteString(titleStyle.Render(i18n.T(i18n.HostDetailsTitle)))
 	s.WriteString("\n")
-	s.WriteString(detailsBoxStyle.Render(details.String()))
+	s.WriteString(detailsBoxStyle.Render(hostDetails(m.selectedHost, m.usage)))
 	s.WriteString("\n\n")
 	s.WriteString(helpStyle.Render(i18n.T(i18n.PressAnyKeyToReturn)))
 
@@ -706,7 +1319,7 @@ func (m AppModel) renderConfirmDeleteKey() string {
 	s.WriteString("\n\n")
 	s.WriteString(statusStyle.Render(fmt.Sprintf(i18n.T(i18n.ConfirmDeleteKey), m.selectedHost.KeyFile)))
 	s.WriteString("\n\n")
-	s.WriteString(helpStyle.Render("y: " + i18n.T(i18n.KeyConfirm) + " • n/esc: " + i18n.T(i18n.KeyCancel)))
+	s.WriteString(helpStyle.Render(m.keys.confirmHelp()))
 
 	return s.String()
 }
@@ -719,7 +1332,9 @@ func (m AppModel) renderConfirmDeleteConfig() string {
 	s.WriteString("\n\n")
 	s.WriteString(statusStyle.Render(fmt.Sprintf(i18n.T(i18n.ConfirmDeleteConfig), m.selectedHost.Host)))
 	s.WriteString("\n\n")
-	s.WriteString(helpStyle.Render("y: " + i18n.T(i18n.KeyConfirm) + " • n/esc: " + i18n.T(i18n.KeyCancel)))
+	s.WriteString(m.renderDiff(m.diffPreview))
+	s.WriteString("\n\n")
+	s.WriteString(helpStyle.Render(m.keys.confirmHelp()))
 
 	return s.String()
 }
@@ -737,7 +1352,7 @@ func (m AppModel) renderInputUsername() string {
 	s.WriteString("\n\n")
 	s.WriteString(inputStyle.Render(m.textInput.View()))
 	s.WriteString("\n\n")
-	s.WriteString(helpStyle.Render("enter: " + i18n.T(i18n.KeyConfirm) + " • esc: " + i18n.T(i18n.KeyCancel)))
+	s.WriteString(helpStyle.Render(m.keys.inputHelp()))
 
 	return s.String()
 }
@@ -750,25 +1365,68 @@ func (m AppModel) renderInputPort() string {
 	s.WriteString("\n\n")
 	s.WriteString(inputStyle.Render(m.textInput.View()))
 	s.WriteString("\n\n")
-	s.WriteString(helpStyle.Render("enter: " + i18n.T(i18n.KeyConfirm) + " • esc: " + i18n.T(i18n.KeyCancel)))
+	s.WriteString(helpStyle.Render(m.keys.inputHelp()))
 
 	return s.String()
 }
 
-// ============================================================================
-// 导出方法
-// ============================================================================
+// renderInputTags 渲染标签/分组输入
+func (m AppModel) renderInputTags() string {
+	var s strings.Builder
 
-// GetResultAction 获取结果操作
-func (m AppModel) GetResultAction() ActionType {
-	return m.resultAction
+	s.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T(i18n.EnterHostMeta), m.selectedHost.Host)))
+	s.WriteString("\n\n")
+	s.WriteString(inputStyle.Render(m.textInput.View()))
+	s.WriteString("\n\n")
+	s.WriteString(helpStyle.Render(m.keys.inputHelp()))
+
+	return s.String()
 }
 
-// GetResultHost 获取结果主机
-func (m AppModel) GetResultHost() *ssh.SSHHost {
-	return m.resultHost
+// renderInputRemoteCommand 渲染远程命令输入
+func (m AppModel) renderInputRemoteCommand() string {
+	var s strings.Builder
+
+	title := fmt.Sprintf(i18n.T(i18n.EnterRemoteCommand), m.selectedHost.Host)
+	if m.state == stateInputConnectRun {
+		title = fmt.Sprintf(i18n.T(i18n.EnterConnectRunCommand), m.selectedHost.Host)
+	}
+
+	s.WriteString(titleStyle.Render(title))
+	s.WriteString("\n\n")
+	s.WriteString(inputStyle.Render(m.textInput.View()))
+	s.WriteString("\n\n")
+	if m.state == stateInputRemoteCommand {
+		s.WriteString(statusStyle.Render(i18n.T(i18n.RemoteCommandHint)))
+		s.WriteString("\n")
+	}
+	s.WriteString(helpStyle.Render(m.keys.inputHelp()))
+
+	return s.String()
+}
+
+// renderInputAlias 渲染别名输入
+func (m AppModel) renderInputAlias() string {
+	var s strings.Builder
+
+	title := fmt.Sprintf(i18n.T(i18n.EnterRenameAlias), m.selectedHost.Host)
+	if m.state == stateInputDuplicate {
+		title = fmt.Sprintf(i18n.T(i18n.EnterDuplicateAlias), m.selectedHost.Host)
+	}
+
+	s.WriteString(titleStyle.Render(title))
+	s.WriteString("\n\n")
+	s.WriteString(inputStyle.Render(m.textInput.View()))
+	s.WriteString("\n\n")
+	s.WriteString(helpStyle.Render(m.keys.inputHelp()))
+
+	return s.String()
 }
 
+// ============================================================================
+// 导出方法
+// ============================================================================
+
 // IsQuitting 是否正在退出
 func (m AppModel) IsQuitting() bool {
 	return m.quitting
@@ -778,25 +1436,18 @@ func (m AppModel) IsQuitting() bool {
 // 入口函数
 // ============================================================================
 
-// Run 运行主应用
-func Run(hosts []ssh.SSHHost, configPath string) (ActionType, *ssh.SSHHost, error) {
+// Run 运行主应用，连接、网络诊断和文件浏览都在同一个程序中完成；filter 非空时主机列表以该关键词预先过滤
+func Run(hosts []ssh.SSHHost, configPath string, filter string) error {
 	model := NewAppModel(hosts, configPath)
-	p := tea.NewProgram(model, tea.WithAltScreen())
-
-	finalModel, err := p.Run()
-	if err != nil {
-		return ActionNone, nil, err
-	}
-
-	m, ok := finalModel.(AppModel)
-	if !ok {
-		return ActionNone, nil, fmt.Errorf("unexpected model type")
+	if filter != "" {
+		model.hostList.SetFilterText(filter)
 	}
-
-	return m.GetResultAction(), m.GetResultHost(), nil
+	p := tea.NewProgram(model, append([]tea.ProgramOption{tea.WithAltScreen()}, mouseOptions()...)...)
+	_, err := p.Run()
+	return err
 }
 
-// RunLoop 运行主循环
+// RunLoop 运行主界面
 func RunLoop() {
 	// 获取SSH配置文件路径
 	configPath := ssh.GetSSHConfigPath()
@@ -813,46 +1464,9 @@ func RunLoop() {
 		return
 	}
 
-	for {
-		action, host, err := Run(hosts, configPath)
-		if err != nil {
-			fmt.Printf(i18n.T(i18n.ProgramError)+"\n", err)
-			return
-		}
-
-		switch action {
-		case ActionExit, ActionNone:
-			fmt.Println(i18n.T(i18n.Goodbye))
-			return
-
-		case ActionConnect:
-			if host != nil {
-				err = ssh.ConnectToHost(*host)
-				if err != nil {
-					fmt.Printf("%v\n", err)
-				}
-			}
-
-		case ActionNetworkDiagnostics:
-			if host != nil {
-				err = RunNetworkDiagnostics(*host)
-				if err != nil {
-					fmt.Printf("%v\n", err)
-				}
-			}
-
-		case ActionDeleteConfig:
-			// 重新加载主机列表
-			fmt.Println(i18n.T(i18n.ConfigDeletedReloading))
-			hosts, err = ssh.ParseSSHConfig(configPath)
-			if err != nil {
-				fmt.Printf("%v\n", err)
-				return
-			}
-			if len(hosts) == 0 {
-				fmt.Println(i18n.T(i18n.NoSSHHostsFound))
-				return
-			}
-		}
+	if err := Run(hosts, configPath, ""); err != nil {
+		fmt.Printf(i18n.T(i18n.ProgramError)+"\n", err)
+		return
 	}
-}
\ No newline at end of file
+	fmt.Println(i18n.T(i18n.Goodbye))
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sshgo/i18n"
+	"sshgo/operations"
+	"sshgo/ssh"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// backupItem 备份列表项
+type backupItem struct {
+	backup ssh.Backup
+}
+
+func (i backupItem) Title() string       { return i.backup.Time.Format("2006-01-02 15:04:05") }
+func (i backupItem) Description() string { return i.backup.Source }
+func (i backupItem) FilterValue() string { return i.backup.Source }
+
+// newBackupList 创建备份列表（包含 config 与 known_hosts 的备份）
+func newBackupList(keys keyMap) (list.Model, error) {
+	var items []list.Item
+	for _, source := range []string{ssh.GetSSHConfigPath(), ssh.GetKnownHostsPath()} {
+		backups, err := ssh.ListBackups(source)
+		if err != nil {
+			return list.Model{}, err
+		}
+		for _, b := range backups {
+			items = append(items, backupItem{backup: b})
+		}
+	}
+
+	delegate := newListDelegate()
+	delegate.ShowDescription = true
+	l := list.New(items, delegate, 0, 0)
+	l.Title = fmt.Sprintf(i18n.T(i18n.SelectBackupLabel), "ssh")
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(true)
+	l.DisableQuitKeybindings()
+	keys.applyTo(&l)
+	styleList(&l)
+	return l, nil
+}
+
+// updateSelectBackup 更新备份选择状态
+func (m AppModel) updateSelectBackup(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Back, m.keys.Quit):
+			m.state = stateActionMenu
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			item, ok := m.backupList.SelectedItem().(backupItem)
+			if !ok {
+				return m, nil
+			}
+			diff, err := ssh.PreviewRestore(item.backup)
+			if err != nil {
+				m.message = err.Error()
+				m.isError = true
+				m.state = stateActionMenu
+				return m, nil
+			}
+			m.selectedBackup = item.backup
+			m.diffPreview = diff
+			m.state = stateConfirmRestoreBackup
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.backupList, cmd = m.backupList.Update(msg)
+	return m, cmd
+}
+
+// updateConfirmRestoreBackup 更新确认恢复备份状态
+func (m AppModel) updateConfirmRestoreBackup(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Yes):
+			if err := operations.RestoreBackup(m.selectedBackup); err != nil {
+				if m.checkConflict(err, msg) {
+					return m, nil
+				}
+				m.message = err.Error()
+				m.isError = true
+				m.state = stateActionMenu
+				return m, nil
+			}
+			m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullyRestoredBackup), m.selectedBackup.Source)
+			m.isError = false
+			// 恢复后重新加载主机列表
+			m.state = stateHostList
+			cmd := m.reloadHosts()
+			return m, cmd
+		case key.Matches(msg, m.keys.No, m.keys.Back):
+			m.message = i18n.T(i18n.CancelOperation)
+			m.isError = false
+			m.state = stateSelectBackup
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// renderConfirmRestoreBackup 渲染确认恢复备份
+func (m AppModel) renderConfirmRestoreBackup() string {
+	var s strings.Builder
+
+	s.WriteString(warningStyle.Render("⚠ " + i18n.T(i18n.KeyConfirm)))
+	s.WriteString("\n\n")
+	s.WriteString(statusStyle.Render(fmt.Sprintf(i18n.T(i18n.ConfirmRestoreBackup),
+		m.selectedBackup.Source, m.selectedBackup.Time.Format("2006-01-02 15:04:05"))))
+	s.WriteString("\n\n")
+	s.WriteString(m.renderDiff(m.diffPreview))
+	s.WriteString("\n\n")
+	s.WriteString(helpStyle.Render(m.keys.confirmHelp()))
+
+	return s.String()
+}
+
+// renderDiff 渲染统一 diff，超出窗口高度的部分会被截断
+func (m AppModel) renderDiff(diff string) string {
+	if diff == "" {
+		return statusStyle.Render(i18n.T(i18n.NoChangesToApply))
+	}
+
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	limit := max(m.height-12, 5)
+
+	var s strings.Builder
+	s.WriteString(statusStyle.Render(i18n.T(i18n.DiffPreviewTitle)))
+	for i, line := range lines {
+		if i >= limit {
+			s.WriteString("\n")
+			s.WriteString(statusStyle.Render(fmt.Sprintf(i18n.T(i18n.DiffTruncated), len(lines)-limit)))
+			break
+		}
+		s.WriteString("\n")
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			s.WriteString(diffHeaderStyle.Render(line))
+		case strings.HasPrefix(line, "@@"):
+			s.WriteString(diffHunkStyle.Render(line))
+		case strings.HasPrefix(line, "+"):
+			s.WriteString(diffAddStyle.Render(line))
+		case strings.HasPrefix(line, "-"):
+			s.WriteString(diffDelStyle.Render(line))
+		default:
+			s.WriteString(diffContextStyle.Render(line))
+		}
+	}
+	return s.String()
+}
@@ -0,0 +1,511 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/network"
+	"sshgo/operations"
+	"sshgo/settings"
+	"sshgo/ssh"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// 批量操作类型
+const (
+	ActionBatchSetUser ActionType = "batch_set_user"
+	ActionBatchSetPort ActionType = "batch_set_port"
+	ActionBatchSetKey  ActionType = "batch_set_key"
+	ActionBatchDelete  ActionType = "batch_delete"
+	ActionBatchCheck   ActionType = "batch_check"
+	ActionBatchTiled   ActionType = "batch_tiled"
+	ActionBatchExport  ActionType = "batch_export"
+	ActionBatchClear   ActionType = "batch_clear"
+)
+
+// reachabilityResult 单个主机的可达性检查结果
+type reachabilityResult struct {
+	alias string
+	rtt   time.Duration
+	err   error
+	done  bool
+}
+
+// reachabilityState 可达性检查进度
+type reachabilityState struct {
+	hosts   []ssh.SSHHost
+	results map[string]reachabilityResult
+}
+
+// reachabilityMsg 可达性检查结果消息
+type reachabilityMsg struct {
+	result reachabilityResult
+	ch     <-chan reachabilityResult
+}
+
+// reachabilityDoneMsg 可达性检查全部完成
+type reachabilityDoneMsg struct{}
+
+// tiledDoneMsg 平铺窗格会话结束（断开或退出 tmux）
+type tiledDoneMsg struct {
+	err error
+}
+
+func init() {
+	registerCommands(
+		paletteCommand{
+			title:     i18n.CommandBatchMenu,
+			binding:   func(k keyMap) key.Binding { return k.Enter },
+			available: func(m AppModel) bool { return m.state == stateHostList && len(m.marked) > 0 },
+			run:       func(m AppModel) (tea.Model, tea.Cmd) { return m.openBatchMenu() },
+		},
+		paletteCommand{
+			title:     i18n.CommandMarkAll,
+			binding:   func(k keyMap) key.Binding { return k.MarkAll },
+			available: func(m AppModel) bool { return m.state == stateHostList },
+			run: func(m AppModel) (tea.Model, tea.Cmd) {
+				cmd := m.toggleMarkAll()
+				return m, cmd
+			},
+		},
+		paletteCommand{
+			title:     i18n.CommandClearMarks,
+			binding:   func(k keyMap) key.Binding { return k.Back },
+			available: func(m AppModel) bool { return m.state == stateHostList && len(m.marked) > 0 },
+			run: func(m AppModel) (tea.Model, tea.Cmd) {
+				cmd := m.clearMarks()
+				return m, cmd
+			},
+		},
+	)
+}
+
+// toggleMark 切换当前主机的选择状态
+func (m *AppModel) toggleMark() tea.Cmd {
+	item, ok := m.hostList.SelectedItem().(hostItem)
+	if !ok {
+		return nil
+	}
+	if m.marked[item.host.Host] {
+		delete(m.marked, item.host.Host)
+	} else {
+		m.marked[item.host.Host] = true
+	}
+	index := m.hostList.Index()
+	cmd := m.refreshHostItems()
+	m.hostList.Select(index)
+	return cmd
+}
+
+// toggleMarkAll 选择当前过滤结果中的所有主机；若已全部选择则取消选择
+func (m *AppModel) toggleMarkAll() tea.Cmd {
+	var visible []string
+	for _, item := range m.hostList.VisibleItems() {
+		if h, ok := item.(hostItem); ok {
+			visible = append(visible, h.host.Host)
+		}
+	}
+
+	allMarked := true
+	for _, alias := range visible {
+		if !m.marked[alias] {
+			allMarked = false
+			break
+		}
+	}
+	for _, alias := range visible {
+		if allMarked {
+			delete(m.marked, alias)
+		} else {
+			m.marked[alias] = true
+		}
+	}
+
+	index := m.hostList.Index()
+	cmd := m.refreshHostItems()
+	m.hostList.Select(index)
+	return cmd
+}
+
+// clearMarks 清除所有选择
+func (m *AppModel) clearMarks() tea.Cmd {
+	m.marked = make(map[string]bool)
+	index := m.hostList.Index()
+	cmd := m.refreshHostItems()
+	m.hostList.Select(index)
+	return cmd
+}
+
+// markedHosts 按配置顺序返回已选择的主机
+func (m AppModel) markedHosts() []ssh.SSHHost {
+	var hosts []ssh.SSHHost
+	for _, h := range m.hosts {
+		if m.marked[h.Host] {
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts
+}
+
+// markedAliases 返回已选择主机的别名，用于界面展示
+func (m AppModel) markedAliases() string {
+	var aliases []string
+	for _, h := range m.markedHosts() {
+		aliases = append(aliases, h.Host)
+	}
+	return strings.Join(aliases, ", ")
+}
+
+// openBatchMenu 打开批量操作菜单
+func (m AppModel) openBatchMenu() (tea.Model, tea.Cmd) {
+	items := []list.Item{
+		actionItem{action: ActionBatchSetUser, label: i18n.T(i18n.BatchSetUserAction)},
+		actionItem{action: ActionBatchSetPort, label: i18n.T(i18n.BatchSetPortAction)},
+		actionItem{action: ActionBatchSetKey, label: i18n.T(i18n.BatchSetKeyAction)},
+		actionItem{action: ActionBatchDelete, label: i18n.T(i18n.BatchDeleteAction)},
+		actionItem{action: ActionBatchRun, label: i18n.T(i18n.BatchRunAction)},
+		actionItem{action: ActionBatchTiled, label: i18n.T(i18n.BatchTiledAction)},
+		actionItem{action: ActionBatchCheck, label: i18n.T(i18n.BatchCheckAction)},
+		actionItem{action: ActionBatchExport, label: i18n.T(i18n.BatchExportAction)},
+		actionItem{action: ActionBatchClear, label: i18n.T(i18n.BatchClearAction)},
+		actionItem{action: ActionBack, label: i18n.T(i18n.BackAction)},
+	}
+
+	delegate := newListDelegate()
+	delegate.ShowDescription = false
+	l := list.New(items, delegate, m.width-4, max(m.height, 5))
+	l.Title = fmt.Sprintf(i18n.T(i18n.BatchActionTitle), len(m.markedHosts()))
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.SetShowHelp(true)
+	l.DisableQuitKeybindings()
+	m.keys.applyTo(&l)
+	styleList(&l)
+
+	m.batchList = l
+	m.state = stateBatchMenu
+	m.message = ""
+	return m, nil
+}
+
+// updateBatchMenu 更新批量操作菜单状态
+func (m AppModel) updateBatchMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tiledDoneMsg); ok {
+		if msg.err != nil {
+			m.message = msg.err.Error()
+			m.isError = true
+		}
+		cmd := m.reloadUsage()
+		return m, cmd
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Back, m.keys.Quit):
+			m.state = stateHostList
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			if item, ok := m.batchList.SelectedItem().(actionItem); ok {
+				return m.handleBatchAction(item.action)
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.batchList, cmd = m.batchList.Update(msg)
+	return m, cmd
+}
+
+// handleBatchAction 处理批量操作
+func (m AppModel) handleBatchAction(action ActionType) (tea.Model, tea.Cmd) {
+	m.message = ""
+	m.isError = false
+
+	switch action {
+	case ActionBatchSetUser, ActionBatchSetPort, ActionBatchSetKey, ActionBatchExport:
+		placeholder := ""
+		switch action {
+		case ActionBatchSetUser:
+			m.batchDirective = "User"
+			placeholder = ssh.DefaultUsername()
+		case ActionBatchSetPort:
+			m.batchDirective = "Port"
+			placeholder = "22"
+		case ActionBatchSetKey:
+			m.batchDirective = "IdentityFile"
+			placeholder = "~/.ssh/id_ed25519"
+		case ActionBatchExport:
+			m.batchDirective = ""
+			placeholder = "sshgo-export.conf"
+		}
+		m.textInput.SetValue("")
+		m.textInput.Placeholder = placeholder
+		m.textInput.Focus()
+		m.state = stateBatchInput
+		return m, textinput.Blink
+
+	case ActionBatchDelete:
+		diff, err := ssh.PreviewRemoveHosts(ssh.HostAliases(m.markedHosts()))
+		if err != nil {
+			m.message = err.Error()
+			m.isError = true
+			return m, nil
+		}
+		m.diffPreview = diff
+		m.state = stateConfirmBatchDelete
+		return m, nil
+
+	case ActionBatchRun:
+		return m.openRunInput()
+
+	case ActionBatchTiled:
+		hosts := m.markedHosts()
+		cmd, err := operations.ConnectTiled(hosts)
+		if err != nil {
+			m.message = err.Error()
+			m.isError = true
+			return m, nil
+		}
+		if cmd == nil {
+			m.message = fmt.Sprintf(i18n.T(i18n.OpenedTiledPanes), len(hosts))
+			refresh := m.reloadUsage()
+			return m, refresh
+		}
+		// 不在 tmux 中时，暂停界面并接入新建的 tmux 会话
+		return m, tea.ExecProcess(cmd, func(err error) tea.Msg { return tiledDoneMsg{err: err} })
+
+	case ActionBatchCheck:
+		return m.startReachabilityCheck()
+
+	case ActionBatchClear:
+		m.state = stateHostList
+		cmd := m.clearMarks()
+		return m, cmd
+
+	case ActionBack:
+		m.state = stateHostList
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// updateBatchInput 更新批量修改/导出的输入状态
+func (m AppModel) updateBatchInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Enter):
+			value := strings.TrimSpace(m.textInput.Value())
+			if value == "" {
+				value = m.textInput.Placeholder
+			}
+			hosts := m.markedHosts()
+
+			// 导出不修改配置，完成后留在批量菜单
+			if m.batchDirective == "" {
+				if err := operations.ExportHosts(hosts, value); err != nil {
+					m.message = err.Error()
+					m.isError = true
+					return m, nil
+				}
+				m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullyExportedHosts), len(hosts), value)
+				m.isError = false
+				m.state = stateBatchMenu
+				return m, nil
+			}
+
+			if m.batchDirective == "Port" {
+				if err := ssh.ValidatePort(value); err != nil {
+					m.message = err.Error()
+					m.isError = true
+					return m, nil
+				}
+			}
+			if err := operations.BatchSetDirective(hosts, m.batchDirective, value); err != nil {
+				if m.checkConflict(err, msg) {
+					return m, nil
+				}
+				m.message = err.Error()
+				m.isError = true
+				return m, nil
+			}
+			m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullyBatchSet), m.batchDirective, value, len(hosts))
+			m.isError = false
+			m.state = stateHostList
+			m.marked = make(map[string]bool)
+			cmd := m.reloadHosts()
+			return m, cmd
+		case key.Matches(msg, m.keys.Back):
+			m.message = i18n.T(i18n.CancelOperation)
+			m.isError = false
+			m.state = stateBatchMenu
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// updateConfirmBatchDelete 更新批量删除确认状态
+func (m AppModel) updateConfirmBatchDelete(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Yes):
+			hosts := m.markedHosts()
+			if err := operations.BatchDeleteHosts(hosts); err != nil {
+				if m.checkConflict(err, msg) {
+					return m, nil
+				}
+				m.message = err.Error()
+				m.isError = true
+				m.state = stateBatchMenu
+				return m, nil
+			}
+			m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullyBatchDeleted), len(hosts))
+			m.isError = false
+			m.state = stateHostList
+			m.marked = make(map[string]bool)
+			cmd := m.reloadHosts()
+			return m, cmd
+		case key.Matches(msg, m.keys.No, m.keys.Back):
+			m.message = i18n.T(i18n.CancelOperation)
+			m.isError = false
+			m.state = stateBatchMenu
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// startReachabilityCheck 并发检查所有已选择主机的 TCP 可达性
+func (m AppModel) startReachabilityCheck() (tea.Model, tea.Cmd) {
+	hosts := m.markedHosts()
+	m.reachability = reachabilityState{hosts: hosts, results: make(map[string]reachabilityResult)}
+	m.state = stateBatchCheck
+
+	ch := make(chan reachabilityResult, len(hosts))
+	go func() {
+		var wg sync.WaitGroup
+		cfg := settings.Current().Batch
+		sem := make(chan struct{}, cfg.CheckWorkers)
+		for _, h := range hosts {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(h ssh.SSHHost) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				rtt, err := network.CheckReachable(h.Target(), h.Port, cfg.CheckTimeout.Duration)
+				ch <- reachabilityResult{alias: h.Host, rtt: rtt, err: err, done: true}
+			}(h)
+		}
+		wg.Wait()
+		close(ch)
+	}()
+
+	return m, waitForReachability(ch)
+}
+
+// waitForReachability 等待下一个可达性检查结果
+func waitForReachability(ch <-chan reachabilityResult) tea.Cmd {
+	return func() tea.Msg {
+		result, ok := <-ch
+		if !ok {
+			return reachabilityDoneMsg{}
+		}
+		return reachabilityMsg{result: result, ch: ch}
+	}
+}
+
+// updateBatchCheck 更新可达性检查状态
+func (m AppModel) updateBatchCheck(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case reachabilityMsg:
+		if m.reachability.results != nil {
+			m.reachability.results[msg.result.alias] = msg.result
+		}
+		return m, waitForReachability(msg.ch)
+	case tea.KeyMsg:
+		if key.Matches(msg, m.keys.Back, m.keys.Quit, m.keys.Enter) {
+			m.state = stateBatchMenu
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// renderBatchCheck 渲染可达性检查结果
+func (m AppModel) renderBatchCheck() string {
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T(i18n.ReachabilityTitle), len(m.reachability.hosts))))
+	s.WriteString("\n\n")
+
+	ok, failed, pending := 0, 0, 0
+	for _, h := range m.reachability.hosts {
+		port := h.Port
+		if port == "" {
+			port = "22"
+		}
+		r := m.reachability.results[h.Host]
+		switch {
+		case !r.done:
+			pending++
+			s.WriteString(statusStyle.Render(fmt.Sprintf(i18n.T(i18n.ReachabilityPending), h.Host, h.Target(), port)))
+		case r.err != nil:
+			failed++
+			s.WriteString(errorStyle.Render(fmt.Sprintf(i18n.T(i18n.ReachabilityFailed), h.Host, h.Target(), port, r.err)))
+		default:
+			ok++
+			s.WriteString(successStyle.Render(fmt.Sprintf(i18n.T(i18n.ReachabilityOK), h.Host, h.Target(), port, r.rtt.Round(time.Millisecond))))
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(statusStyle.Render(fmt.Sprintf(i18n.T(i18n.ReachabilitySummary), ok, failed, pending)))
+	s.WriteString("\n")
+	s.WriteString(helpStyle.Render(fmt.Sprintf(i18n.T(i18n.PressEscToReturn), m.keys.Back.Help().Key)))
+	return s.String()
+}
+
+// renderBatchInput 渲染批量修改/导出的输入框
+func (m AppModel) renderBatchInput() string {
+	var s strings.Builder
+
+	count := len(m.markedHosts())
+	title := fmt.Sprintf(i18n.T(i18n.EnterBatchValue), m.batchDirective, count)
+	if m.batchDirective == "" {
+		title = fmt.Sprintf(i18n.T(i18n.EnterExportPath), count)
+	}
+
+	s.WriteString(titleStyle.Render(title))
+	s.WriteString("\n\n")
+	s.WriteString(inputStyle.Render(m.textInput.View()))
+	s.WriteString("\n\n")
+	s.WriteString(helpStyle.Render(m.keys.inputHelp()))
+
+	return s.String()
+}
+
+// renderConfirmBatchDelete 渲染批量删除确认
+func (m AppModel) renderConfirmBatchDelete() string {
+	var s strings.Builder
+
+	hosts := m.markedHosts()
+	s.WriteString(warningStyle.Render("⚠ " + i18n.T(i18n.KeyConfirm)))
+	s.WriteString("\n\n")
+	s.WriteString(statusStyle.Render(fmt.Sprintf(i18n.T(i18n.ConfirmBatchDelete), len(hosts), m.markedAliases())))
+	s.WriteString("\n\n")
+	s.WriteString(m.renderDiff(m.diffPreview))
+	s.WriteString("\n\n")
+	s.WriteString(helpStyle.Render(m.keys.confirmHelp()))
+
+	return s.String()
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sshgo/i18n"
+	"sshgo/ssh"
+	"sshgo/state"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// groupItem 主机列表中的分组标题
+type groupItem struct {
+	name      string // 分组名，空字符串表示未分组
+	count     int
+	collapsed bool
+}
+
+func (i groupItem) Title() string {
+	arrow := "▾"
+	if i.collapsed {
+		arrow = "▸"
+	}
+	name := i.name
+	if name == "" {
+		name = i18n.T(i18n.UngroupedHosts)
+	}
+	return fmt.Sprintf(i18n.T(i18n.GroupHeader), arrow, name, i.count)
+}
+func (i groupItem) Description() string { return "" }
+
+// 分组标题不参与过滤，过滤时只显示匹配的主机
+func (i groupItem) FilterValue() string { return "" }
+
+// hostItemOptions 构建主机列表项时使用的显示状态
+type hostItemOptions struct {
+	collapsed map[string]bool // 已折叠的分组
+	usage     *state.Usage    // 使用记录（收藏）
+	marked    map[string]bool // 多选中已标记的主机
+	expandAll bool            // 过滤时忽略折叠，所有主机都参与过滤
+}
+
+// newHostItem 创建单个主机列表项
+func newHostItem(h ssh.SSHHost, opts hostItemOptions) hostItem {
+	displayName := h.Host
+	if h.HostName != "" && h.HostName != h.Host {
+		displayName = fmt.Sprintf("%s (%s)", h.Host, h.HostName)
+	}
+	if opts.usage.Get(h.Host).Favorite {
+		displayName = "★ " + displayName
+	}
+	if opts.marked[h.Host] {
+		displayName = "✓ " + displayName
+	}
+	return hostItem{host: h, displayName: displayName}
+}
+
+// newHostItems 创建主机列表项；存在分组时按分组排列并插入可折叠的分组标题
+func newHostItems(hosts []ssh.SSHHost, opts hostItemOptions) []list.Item {
+	collapsed := opts.collapsed
+	grouped := false
+	for _, h := range hosts {
+		if h.Group != "" {
+			grouped = true
+			break
+		}
+	}
+
+	if !grouped {
+		items := make([]list.Item, len(hosts))
+		for i, h := range hosts {
+			items[i] = newHostItem(h, opts)
+		}
+		return items
+	}
+
+	// 按分组首次出现的顺序排列，未分组的主机放在最后
+	var order []string
+	members := make(map[string][]ssh.SSHHost)
+	for _, h := range hosts {
+		if _, ok := members[h.Group]; !ok && h.Group != "" {
+			order = append(order, h.Group)
+		}
+		members[h.Group] = append(members[h.Group], h)
+	}
+	if len(members[""]) > 0 {
+		order = append(order, "")
+	}
+
+	var items []list.Item
+	for _, name := range order {
+		hidden := collapsed[name] && !opts.expandAll
+		items = append(items, groupItem{name: name, count: len(members[name]), collapsed: hidden})
+		if hidden {
+			continue
+		}
+		for _, h := range members[name] {
+			items = append(items, newHostItem(h, opts))
+		}
+	}
+	return items
+}
+
+// hostTagsLabel 格式化主机标签用于列表描述
+func hostTagsLabel(h ssh.SSHHost) string {
+	if len(h.Tags) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(h.Tags, ",") + "]"
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+
+	"sshgo/i18n"
+	"sshgo/ssh"
+	"sshgo/state"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// historyItem 连接历史列表项
+type historyItem struct {
+	entry state.HistoryEntry
+}
+
+func (i historyItem) Title() string {
+	target := i.entry.HostName
+	if i.entry.User != "" {
+		target = i.entry.User + "@" + target
+	}
+	if i.entry.Port != "" && i.entry.Port != "22" {
+		target += ":" + i.entry.Port
+	}
+	return fmt.Sprintf("%s  %s", i.entry.Alias, target)
+}
+
+func (i historyItem) Description() string {
+	return i.entry.Summary()
+}
+
+func (i historyItem) FilterValue() string { return i.entry.Alias + " " + i.entry.HostName }
+
+func init() {
+	registerCommands(paletteCommand{
+		title:   i18n.CommandOpenHistory,
+		binding: func(k keyMap) key.Binding { return k.History },
+		run:     func(m AppModel) (tea.Model, tea.Cmd) { return m.openHistory("") },
+	})
+}
+
+// openHistory 打开连接历史界面；alias 非空时只显示该主机的记录
+func (m AppModel) openHistory(alias string) (tea.Model, tea.Cmd) {
+	entries, err := state.LoadHistory(alias)
+	if err != nil {
+		m.message = fmt.Sprintf(i18n.T(i18n.LoadHistoryFailed), err)
+		m.isError = true
+		return m, nil
+	}
+	if len(entries) == 0 {
+		m.message = i18n.T(i18n.NoHistoryFound)
+		m.isError = true
+		return m, nil
+	}
+
+	items := make([]list.Item, len(entries))
+	for i, e := range entries {
+		items[i] = historyItem{entry: e}
+	}
+
+	keys := m.keys
+	delegate := newListDelegate()
+	delegate.ShowDescription = true
+	l := list.New(items, delegate, m.width-4, max(m.height, 5))
+	l.Title = i18n.T(i18n.HistoryTitle)
+	if alias != "" {
+		l.Title = fmt.Sprintf(i18n.T(i18n.HostHistoryTitle), alias)
+	}
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(true)
+	l.SetShowHelp(true)
+	l.DisableQuitKeybindings()
+	keys.applyTo(&l)
+	styleList(&l)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.Reconnect}
+	}
+
+	m.historyList = l
+	m.historyReturnState = m.state
+	m.state = stateHistory
+	m.message = ""
+	return m, nil
+}
+
+// updateHistory 更新连接历史状态
+func (m AppModel) updateHistory(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && m.historyList.FilterState() != list.Filtering {
+		switch {
+		case key.Matches(msg, m.keys.Back, m.keys.Quit):
+			m.state = m.historyReturnState
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			item, ok := m.historyList.SelectedItem().(historyItem)
+			if !ok {
+				return m, nil
+			}
+			m.selectedHost = m.findHost(item.entry)
+			m.state = stateActionMenu
+			return m.handleAction(ActionConnect)
+		}
+	}
+
+	var cmd tea.Cmd
+	m.historyList, cmd = m.historyList.Update(msg)
+	return m, cmd
+}
+
+// findHost 优先使用当前配置中的同名主机，配置已删除时按历史记录还原
+func (m AppModel) findHost(e state.HistoryEntry) ssh.SSHHost {
+	for _, h := range m.hosts {
+		if h.Host == e.Alias {
+			return h
+		}
+	}
+	return e.Host()
+}
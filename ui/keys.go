@@ -0,0 +1,251 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sshgo/i18n"
+	"sshgo/settings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyMap 实际生效的按键，由配置文件中的按键方案生成
+type keyMap struct {
+	profile string
+
+	Up       key.Binding
+	Down     key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+	Top      key.Binding
+	Bottom   key.Binding
+
+	Enter  key.Binding
+	Back   key.Binding
+	Quit   key.Binding
+	Search key.Binding
+	Help   key.Binding
+	Yes    key.Binding
+	No     key.Binding
+
+	Mark       key.Binding
+	MarkAll    key.Binding
+	Sort       key.Binding
+	Pin        key.Binding
+	History    key.Binding
+	Recordings key.Binding
+	Undo       key.Binding
+	Redo       key.Binding
+	Palette    key.Binding
+
+	SpeedUp   key.Binding
+	SpeedDown key.Binding
+	Save      key.Binding
+
+	SwitchPane key.Binding
+	Open       key.Binding
+	Parent     key.Binding
+	Copy       key.Binding
+	Resume     key.Binding
+
+	// 与上面按键相同、仅帮助文本不同的绑定
+	Reconnect key.Binding
+	Play      key.Binding
+	Speed     key.Binding
+}
+
+// keyLabels 帮助中按键的显示名
+var keyLabels = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+}
+
+// keyLabel 返回按键在帮助中的显示名
+func keyLabel(k string) string {
+	if label, ok := keyLabels[k]; ok {
+		return label
+	}
+	return k
+}
+
+// newBinding 创建按键绑定，帮助中显示第一个按键；配置中的 "space" 对应 bubbletea 的 " "
+func newBinding(keys []string, desc string) key.Binding {
+	matches := make([]string, len(keys))
+	for i, k := range keys {
+		if k == "space" {
+			k = " "
+		}
+		matches[i] = k
+	}
+	label := ""
+	if len(keys) > 0 {
+		label = keyLabel(keys[0])
+	}
+	return key.NewBinding(key.WithKeys(matches...), key.WithHelp(label, desc))
+}
+
+// newKeyMap 根据按键配置生成按键绑定
+func newKeyMap(ks settings.KeySettings) keyMap {
+	b := ks.Bindings()
+	k := keyMap{
+		profile: ks.Profile,
+
+		Up:       newBinding(b["up"], i18n.T(i18n.KeyUp)),
+		Down:     newBinding(b["down"], i18n.T(i18n.KeyDown)),
+		PageUp:   newBinding(b["page_up"], i18n.T(i18n.KeyPageUp)),
+		PageDown: newBinding(b["page_down"], i18n.T(i18n.KeyPageDown)),
+		Top:      newBinding(b["top"], i18n.T(i18n.KeyTop)),
+		Bottom:   newBinding(b["bottom"], i18n.T(i18n.KeyBottom)),
+
+		Enter:  newBinding(b["select"], i18n.T(i18n.KeySelect)),
+		Back:   newBinding(b["back"], i18n.T(i18n.KeyBack)),
+		Quit:   newBinding(b["quit"], i18n.T(i18n.KeyQuit)),
+		Search: newBinding(b["search"], i18n.T(i18n.KeySearch)),
+		Help:   newBinding(b["help"], i18n.T(i18n.KeyHelp)),
+		Yes:    newBinding(b["yes"], i18n.T(i18n.KeyConfirm)),
+		No:     newBinding(b["no"], i18n.T(i18n.KeyCancel)),
+
+		Mark:       newBinding(b["mark"], i18n.T(i18n.KeyMark)),
+		MarkAll:    newBinding(b["mark_all"], i18n.T(i18n.KeyMarkAll)),
+		Sort:       newBinding(b["sort"], i18n.T(i18n.KeySort)),
+		Pin:        newBinding(b["pin"], i18n.T(i18n.KeyFavorite)),
+		History:    newBinding(b["history"], i18n.T(i18n.KeyHistory)),
+		Recordings: newBinding(b["recordings"], i18n.T(i18n.KeyRecordings)),
+		Undo:       newBinding(b["undo"], i18n.T(i18n.KeyUndo)),
+		Redo:       newBinding(b["redo"], i18n.T(i18n.KeyRedo)),
+		Palette:    newBinding(b["palette"], i18n.T(i18n.KeyPalette)),
+
+		SpeedUp:   newBinding(b["speed_up"], i18n.T(i18n.KeySpeedUp)),
+		SpeedDown: newBinding(b["speed_down"], i18n.T(i18n.KeySpeedDown)),
+		Save:      newBinding(b["save"], i18n.T(i18n.KeySaveOutput)),
+
+		SwitchPane: newBinding(b["switch_pane"], i18n.T(i18n.KeySwitchPane)),
+		Open:       newBinding(b["open"], i18n.T(i18n.KeyOpen)),
+		Parent:     newBinding(b["parent"], i18n.T(i18n.KeyParent)),
+		Copy:       newBinding(b["copy"], i18n.T(i18n.KeyCopy)),
+		Resume:     newBinding(b["resume"], i18n.T(i18n.KeyResume)),
+
+		Reconnect: newBinding(b["select"], i18n.T(i18n.KeyReconnect)),
+		Play:      newBinding(b["select"], i18n.T(i18n.KeyPlay)),
+	}
+	k.Speed = newBinding(append(append([]string{}, b["speed_up"]...), b["speed_down"]...), i18n.T(i18n.KeySpeed))
+	k.Speed.SetHelp(k.SpeedUp.Help().Key+"/"+k.SpeedDown.Help().Key, i18n.T(i18n.KeySpeed))
+	return k
+}
+
+// getKeys 返回当前配置的按键绑定
+func getKeys() keyMap {
+	return newKeyMap(settings.Current().Keys)
+}
+
+// applyTo 将按键方案应用到列表组件
+// 列表的完整帮助由 ? 帮助界面代替，帮助键由调用方在列表之前处理
+func (k keyMap) applyTo(l *list.Model) {
+	l.KeyMap.CursorUp = k.Up
+	l.KeyMap.CursorDown = k.Down
+	l.KeyMap.PrevPage = k.PageUp
+	l.KeyMap.NextPage = k.PageDown
+	l.KeyMap.GoToStart = k.Top
+	l.KeyMap.GoToEnd = k.Bottom
+	l.KeyMap.Filter = k.Search
+	l.KeyMap.ClearFilter = k.Back
+	l.KeyMap.ShowFullHelp = k.Help
+	l.KeyMap.CloseFullHelp = k.Help
+}
+
+// hostListHelp 主机列表底部帮助中额外显示的按键
+func (k keyMap) hostListHelp() []key.Binding {
+	return []key.Binding{k.Mark, k.Sort, k.Pin, k.Palette}
+}
+
+// confirmHelp 确认对话框的帮助文本
+func (k keyMap) confirmHelp() string {
+	return fmt.Sprintf("%s: %s • %s/%s: %s",
+		k.Yes.Help().Key, i18n.T(i18n.KeyConfirm), k.No.Help().Key, k.Back.Help().Key, i18n.T(i18n.KeyCancel))
+}
+
+// inputHelp 输入框的帮助文本
+func (k keyMap) inputHelp() string {
+	return fmt.Sprintf("%s: %s • %s: %s",
+		k.Enter.Help().Key, i18n.T(i18n.KeyConfirm), k.Back.Help().Key, i18n.T(i18n.KeyCancel))
+}
+
+// keyNames 列出绑定的全部按键
+func keyNames(b key.Binding) string {
+	names := make([]string, len(b.Keys()))
+	for i, k := range b.Keys() {
+		if k == " " {
+			k = "space"
+		}
+		names[i] = keyLabel(k)
+	}
+	return strings.Join(names, " ")
+}
+
+// keyHelpSection 帮助界面中的一组按键
+type keyHelpSection struct {
+	title    i18n.StringKey
+	bindings []key.Binding
+}
+
+// renderKeyHelpColumn 渲染帮助界面的一栏，按键名按本栏最长的对齐
+func renderKeyHelpColumn(sections []keyHelpSection) string {
+	width := 0
+	for _, section := range sections {
+		for _, b := range section.bindings {
+			width = max(width, lipgloss.Width(keyNames(b)))
+		}
+	}
+	keyStyle := lipgloss.NewStyle().Bold(true).Width(width + 2)
+
+	var s strings.Builder
+	for i, section := range sections {
+		if i > 0 {
+			s.WriteString("\n\n")
+		}
+		s.WriteString(diffHeaderStyle.UnsetMarginLeft().Render(i18n.T(section.title)))
+		for _, b := range section.bindings {
+			s.WriteString("\n")
+			s.WriteString(keyStyle.Render(keyNames(b)) + b.Help().Desc)
+		}
+	}
+	return s.String()
+}
+
+// renderKeyHelp 渲染按键帮助界面，分两栏列出实际生效的按键
+func (k keyMap) renderKeyHelp() string {
+	forceQuit := key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", i18n.T(i18n.KeyForceQuit)))
+	left := renderKeyHelpColumn([]keyHelpSection{
+		{i18n.KeyHelpGeneral, []key.Binding{k.Enter, k.Back, k.Quit, k.Search, k.Help, k.Palette, forceQuit}},
+		{i18n.KeyHelpNavigation, []key.Binding{k.Up, k.Down, k.PageUp, k.PageDown, k.Top, k.Bottom}},
+		{i18n.KeyHelpDialogs, []key.Binding{k.Yes, k.No}},
+		{i18n.KeyHelpRun, []key.Binding{k.Save}},
+	})
+	rightSections := []keyHelpSection{
+		{i18n.KeyHelpHostList, []key.Binding{k.Mark, k.MarkAll, k.Sort, k.Pin, k.History, k.Recordings, k.Undo, k.Redo}},
+		{i18n.KeyHelpRecordings, []key.Binding{k.SpeedUp, k.SpeedDown}},
+		{i18n.KeyHelpFiles, []key.Binding{k.SwitchPane, k.Open, k.Parent, k.Copy, k.Resume}},
+	}
+	if settings.Current().Keys.Mouse {
+		rightSections = append(rightSections, keyHelpSection{i18n.KeyHelpMouse, []key.Binding{
+			key.NewBinding(key.WithKeys("click"), key.WithHelp("click", i18n.T(i18n.MouseClick))),
+			key.NewBinding(key.WithKeys("double-click"), key.WithHelp("double-click", i18n.T(i18n.MouseDoubleClick))),
+			key.NewBinding(key.WithKeys("wheel"), key.WithHelp("wheel", i18n.T(i18n.MouseWheel))),
+		}})
+	}
+	right := renderKeyHelpColumn(rightSections)
+	body := lipgloss.JoinHorizontal(lipgloss.Top, left, "    ", right)
+
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T(i18n.KeyHelpTitle), k.profile)))
+	s.WriteString("\n")
+	s.WriteString(runPaneStyle.Render(body))
+	s.WriteString("\n")
+	s.WriteString(helpStyle.Render(fmt.Sprintf(i18n.T(i18n.KeyHelpFooter), k.Enter.Help().Key, k.Back.Help().Key)))
+	return s.String()
+}
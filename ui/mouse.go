@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"time"
+
+	"sshgo/settings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// doubleClickInterval 两次点击同一项的最大间隔，视为双击
+const doubleClickInterval = 400 * time.Millisecond
+
+// 列表项占用的行数：主机列表显示描述，菜单只显示标题
+const (
+	hostItemHeight = 2
+	menuItemHeight = 1
+)
+
+// mouseOptions 按配置启用鼠标：只上报按下与拖动，避免移动鼠标时频繁刷新
+func mouseOptions() []tea.ProgramOption {
+	if !settings.Current().Keys.Mouse {
+		return nil
+	}
+	return []tea.ProgramOption{tea.WithMouseCellMotion()}
+}
+
+// listItemAt 返回列表中第 y 行对应的项在过滤后列表中的位置，不是列表项时返回 -1
+// 列表须从界面第一行开始渲染，itemHeight 为每项的行数（不含项之间的空行）
+func listItemAt(l list.Model, y, itemHeight int) int {
+	if l.FilterState() == list.Filtering && len(l.VisibleItems()) == 0 {
+		return -1
+	}
+	top := 0
+	if l.ShowTitle() || (l.ShowFilter() && l.FilteringEnabled()) {
+		top += lipgloss.Height(l.Styles.TitleBar.Render("x"))
+	}
+	if l.ShowStatusBar() {
+		top += lipgloss.Height(l.Styles.StatusBar.Render("x"))
+	}
+	if y < top {
+		return -1
+	}
+
+	row := itemHeight + list.NewDefaultDelegate().Spacing()
+	if y-top >= l.Paginator.PerPage*row || (y-top)%row >= itemHeight {
+		return -1
+	}
+	index := l.Paginator.Page*l.Paginator.PerPage + (y-top)/row
+	if index >= len(l.VisibleItems()) {
+		return -1
+	}
+	return index
+}
+
+// mouseClick 记录上一次点击，用于识别双击
+type mouseClick struct {
+	index int
+	at    time.Time
+}
+
+// handleListMouse 处理列表中的鼠标：滚轮移动光标，左键点击选中该项
+// clicked 为点击的项的位置（未点击列表项时为 -1），double 表示与上一次点击是同一项的双击
+func handleListMouse(l *list.Model, itemHeight int, last *mouseClick, msg tea.MouseMsg) (clicked int, double bool) {
+	if msg.Action != tea.MouseActionPress {
+		return -1, false
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		l.CursorUp()
+	case tea.MouseButtonWheelDown:
+		l.CursorDown()
+	case tea.MouseButtonLeft:
+		index := listItemAt(*l, msg.Y, itemHeight)
+		if index < 0 {
+			return -1, false
+		}
+		l.Select(index)
+		now := time.Now()
+		double = last.index == index && now.Sub(last.at) <= doubleClickInterval
+		if double {
+			// 第三次点击重新开始计算
+			*last = mouseClick{index: -1}
+		} else {
+			*last = mouseClick{index: index, at: now}
+		}
+		return index, double
+	}
+	return -1, false
+}
+
+// updateHostListMouse 主机列表中的鼠标：单击选中主机或展开/折叠分组，双击主机直接连接
+func (m AppModel) updateHostListMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	// 输入过滤条件时不响应鼠标；显示预览时只响应左侧列表中的点击
+	if m.hostList.FilterState() == list.Filtering ||
+		(msg.Button == tea.MouseButtonLeft && m.previewVisible() && msg.X >= m.hostListWidth()) {
+		return m, nil
+	}
+	index, double := handleListMouse(&m.hostList, hostItemHeight, &m.lastClick, msg)
+	if index < 0 {
+		return m, nil
+	}
+	switch item := m.hostList.SelectedItem().(type) {
+	case hostItem:
+		if double && len(m.marked) == 0 {
+			m.selectedHost = item.host
+			m.message = ""
+			return m.handleAction(ActionConnect)
+		}
+	case groupItem:
+		// 双击的第二次点击不再切换，避免分组展开后又立即折叠
+		if !double {
+			cmd := m.toggleGroup(item)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
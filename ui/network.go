@@ -7,8 +7,10 @@ import (
 
 	"sshgo/i18n"
 	"sshgo/network"
+	"sshgo/settings"
 	"sshgo/ssh"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -78,11 +80,11 @@ type routeTraceErrorMsg struct {
 
 // NetworkModel 网络诊断模型
 type NetworkModel struct {
-	state    networkState
-	host     ssh.SSHHost
-	menu     list.Model
-	spinner  spinner.Model
-	quitting bool
+	state   networkState
+	host    ssh.SSHHost
+	menu    list.Model
+	spinner spinner.Model
+	keys    keyMap
 
 	// 延迟测试
 	latencyResults []string
@@ -97,10 +99,14 @@ type NetworkModel struct {
 
 	// 错误信息
 	errorMsg string
+
+	// 上一次鼠标点击
+	lastClick mouseClick
 }
 
 // NewNetworkModel 创建网络诊断模型
 func NewNetworkModel(host ssh.SSHHost) NetworkModel {
+	applyTheme()
 	// 创建菜单
 	items := []list.Item{
 		networkMenuItem{id: "latency", label: i18n.T(i18n.MeasureLatencyAction)},
@@ -108,24 +114,28 @@ func NewNetworkModel(host ssh.SSHHost) NetworkModel {
 		networkMenuItem{id: "back", label: i18n.T(i18n.ReturnToMainMenu)},
 	}
 
-	delegate := list.NewDefaultDelegate()
+	delegate := newListDelegate()
 	delegate.ShowDescription = false
 	menu := list.New(items, delegate, 0, 0)
 	menu.Title = fmt.Sprintf(i18n.T(i18n.NetworkDiagnosticsTitle), host.Host)
 	menu.SetShowStatusBar(false)
 	menu.SetFilteringEnabled(false)
 	menu.SetShowHelp(true)
+	keys := getKeys()
+	keys.applyTo(&menu)
+	styleList(&menu)
 
 	// 创建 spinner
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Style = lipgloss.NewStyle().Foreground(theme.accent)
 
 	return NetworkModel{
 		state:   networkStateMenu,
 		host:    host,
 		menu:    menu,
 		spinner: s,
+		keys:    keys,
 		width:   80,
 		height:  24,
 	}
@@ -141,12 +151,11 @@ func (m NetworkModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
-		case msg.String() == "q" || msg.String() == "ctrl+c":
+		case key.Matches(msg, m.keys.Quit):
 			if m.state == networkStateMenu {
-				m.quitting = true
-				return m, tea.Quit
+				return m, closeScreen
 			}
-		case msg.String() == "esc" || msg.String() == "backspace":
+		case key.Matches(msg, m.keys.Back):
 			if m.state != networkStateMenu {
 				m.state = networkStateMenu
 				m.latencyResults = nil
@@ -155,8 +164,7 @@ func (m NetworkModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.errorMsg = ""
 				return m, nil
 			}
-			m.quitting = true
-			return m, tea.Quit
+			return m, closeScreen
 		}
 
 	case tea.WindowSizeMsg:
@@ -239,25 +247,15 @@ func (m NetworkModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // updateMenu 更新菜单状态
 func (m NetworkModel) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		// 点击菜单项直接执行
+		if index, _ := handleListMouse(&m.menu, menuItemHeight, &m.lastClick, msg); index >= 0 {
+			return m.runMenuItem()
+		}
+		return m, nil
 	case tea.KeyMsg:
-		if msg.String() == "enter" {
-			if item, ok := m.menu.SelectedItem().(networkMenuItem); ok {
-				switch item.id {
-				case "latency":
-					m.state = networkStateLatencyTest
-					m.latencyResults = nil
-					m.latencySummary = ""
-					return m, tea.Batch(m.spinner.Tick, m.runLatencyTest())
-				case "trace":
-					m.state = networkStateRouteTrace
-					m.routeHops = nil
-					m.errorMsg = ""
-					return m, tea.Batch(m.spinner.Tick, m.runRouteTrace())
-				case "back":
-					m.quitting = true
-					return m, tea.Quit
-				}
-			}
+		if key.Matches(msg, m.keys.Enter) {
+			return m.runMenuItem()
 		}
 	}
 
@@ -266,6 +264,27 @@ func (m NetworkModel) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// runMenuItem 执行菜单中选中的项
+func (m NetworkModel) runMenuItem() (tea.Model, tea.Cmd) {
+	if item, ok := m.menu.SelectedItem().(networkMenuItem); ok {
+		switch item.id {
+		case "latency":
+			m.state = networkStateLatencyTest
+			m.latencyResults = nil
+			m.latencySummary = ""
+			return m, tea.Batch(m.spinner.Tick, m.runLatencyTest())
+		case "trace":
+			m.state = networkStateRouteTrace
+			m.routeHops = nil
+			m.errorMsg = ""
+			return m, tea.Batch(m.spinner.Tick, m.runRouteTrace())
+		case "back":
+			return m, closeScreen
+		}
+	}
+	return m, nil
+}
+
 // updateLatencyTest 更新延迟测试状态
 func (m NetworkModel) updateLatencyTest(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -288,13 +307,14 @@ func (m NetworkModel) runLatencyTest() tea.Cmd {
 	}
 	return func() tea.Msg {
 		measurer := network.NewLatencyMeasurer()
-		const samples = 5
+		cfg := settings.Current().Network
+		samples := cfg.LatencySamples
 		var sum time.Duration
 		var min, max time.Duration
 		var count int
 
 		for range samples {
-			result, err := measurer.MeasureLatency(host, "tcp")
+			result, err := measurer.MeasureLatency(host, cfg.Protocol)
 			if err != nil {
 				continue
 			}
@@ -373,10 +393,6 @@ func waitForRouteHop(hopChan <-chan network.RouteHop, errChan <-chan error, done
 
 // View 渲染
 func (m NetworkModel) View() string {
-	if m.quitting {
-		return ""
-	}
-
 	var s strings.Builder
 
 	switch m.state {
@@ -401,7 +417,7 @@ func (m NetworkModel) View() string {
 		}
 
 		s.WriteString("\n\n")
-		s.WriteString(helpStyle.Render(i18n.T(i18n.PressEscToReturn)))
+		s.WriteString(helpStyle.Render(fmt.Sprintf(i18n.T(i18n.PressEscToReturn), m.keys.Back.Help().Key)))
 
 	case networkStateRouteTrace:
 		s.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T(i18n.TracingRoute), m.host.Host)))
@@ -421,21 +437,11 @@ func (m NetworkModel) View() string {
 		}
 
 		s.WriteString("\n\n")
-		s.WriteString(helpStyle.Render(i18n.T(i18n.PressEscToReturn)))
+		s.WriteString(helpStyle.Render(fmt.Sprintf(i18n.T(i18n.PressEscToReturn), m.keys.Back.Help().Key)))
 	}
 
 	return s.String()
 }
 
-// IsQuitting 是否正在退出
-func (m NetworkModel) IsQuitting() bool {
-	return m.quitting
-}
-
-// RunNetworkDiagnostics 运行网络诊断
-func RunNetworkDiagnostics(host ssh.SSHHost) error {
-	model := NewNetworkModel(host)
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	_, err := p.Run()
-	return err
-}
+// release 网络诊断没有需要释放的资源，进行中的测试结束后自行退出
+func (m NetworkModel) release() {}
@@ -0,0 +1,334 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"sshgo/i18n"
+	"sshgo/settings"
+	"sshgo/ssh"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// paletteCommand 命令面板中的命令，各界面通过 registerCommands 注册
+type paletteCommand struct {
+	title     i18n.StringKey
+	binding   func(k keyMap) key.Binding // 快捷键提示，为 nil 表示没有快捷键
+	available func(m AppModel) bool      // 当前是否可用，为 nil 表示总是可用
+	run       func(m AppModel) (tea.Model, tea.Cmd)
+}
+
+// paletteCommands 已注册的命令，按注册顺序显示
+var paletteCommands []paletteCommand
+
+// registerCommands 向命令面板注册命令
+func registerCommands(cmds ...paletteCommand) {
+	paletteCommands = append(paletteCommands, cmds...)
+}
+
+func init() {
+	registerCommands(
+		paletteCommand{
+			title:   i18n.CommandUndo,
+			binding: func(k keyMap) key.Binding { return k.Undo },
+			run:     func(m AppModel) (tea.Model, tea.Cmd) { return m.undoRedo(true) },
+		},
+		paletteCommand{
+			title:   i18n.CommandRedo,
+			binding: func(k keyMap) key.Binding { return k.Redo },
+			run:     func(m AppModel) (tea.Model, tea.Cmd) { return m.undoRedo(false) },
+		},
+		paletteCommand{
+			title: i18n.CommandSwitchLanguage,
+			run:   switchLanguage,
+		},
+		paletteCommand{
+			title:   i18n.CommandShowKeys,
+			binding: func(k keyMap) key.Binding { return k.Help },
+			run: func(m AppModel) (tea.Model, tea.Cmd) {
+				m.showKeyHelp = true
+				return m, nil
+			},
+		},
+		paletteCommand{
+			title:   i18n.CommandQuit,
+			binding: func(k keyMap) key.Binding { return k.Quit },
+			run: func(m AppModel) (tea.Model, tea.Cmd) {
+				m.quitting = true
+				return m, tea.Quit
+			},
+		},
+	)
+}
+
+// paletteEntry 面板中的一项：当前主机的操作或已注册的命令
+type paletteEntry struct {
+	title string
+	hint  string
+	run   func(m AppModel) (tea.Model, tea.Cmd)
+}
+
+// paletteState 命令面板：打开时收集当前可用的命令，输入内容模糊匹配命令名
+type paletteState struct {
+	input       textinput.Model
+	entries     []paletteEntry
+	matches     []fuzzy.Match
+	cursor      int
+	returnState appState
+}
+
+// paletteAvailable 是否可以打开命令面板：主机列表（未在输入过滤条件）与操作菜单
+func (m AppModel) paletteAvailable() bool {
+	switch m.state {
+	case stateHostList:
+		return m.hostList.FilterState() != list.Filtering
+	case stateActionMenu:
+		return true
+	}
+	return false
+}
+
+// paletteHost 命令面板中主机操作针对的主机：操作菜单中的主机或主机列表中光标所在的主机（多选时没有）
+func (m AppModel) paletteHost() (ssh.SSHHost, bool) {
+	switch m.state {
+	case stateActionMenu:
+		return m.selectedHost, true
+	case stateHostList:
+		if len(m.marked) == 0 {
+			return m.selectedHostItem()
+		}
+	}
+	return ssh.SSHHost{}, false
+}
+
+// paletteEntries 收集当前可用的命令：先列出主机操作，再列出已注册的命令
+func (m AppModel) paletteEntries() []paletteEntry {
+	var entries []paletteEntry
+	if host, ok := m.paletteHost(); ok {
+		for _, item := range m.actionList.Items() {
+			action := item.(actionItem)
+			if action.action == ActionBack {
+				continue
+			}
+			entries = append(entries, paletteEntry{
+				title: action.label + " · " + host.Host,
+				run: func(m AppModel) (tea.Model, tea.Cmd) {
+					m.selectedHost = host
+					m.state = stateActionMenu
+					return m.handleAction(action.action)
+				},
+			})
+		}
+	}
+	for _, c := range paletteCommands {
+		if c.available != nil && !c.available(m) {
+			continue
+		}
+		entry := paletteEntry{title: i18n.T(c.title), run: c.run}
+		if c.binding != nil {
+			entry.hint = c.binding(m.keys).Help().Key
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// openPalette 打开命令面板
+func (m AppModel) openPalette() (tea.Model, tea.Cmd) {
+	input := textinput.New()
+	input.Placeholder = i18n.T(i18n.PalettePlaceholder)
+	input.Prompt = "> "
+	input.Width = max(m.width-8, 20)
+	input.Focus()
+
+	m.palette = paletteState{
+		input:       input,
+		entries:     m.paletteEntries(),
+		returnState: m.state,
+	}
+	m.palette.filter()
+	m.state = statePalette
+	m.message = ""
+	return m, textinput.Blink
+}
+
+// filter 按输入内容模糊匹配命令名；输入为空时按原有顺序列出全部命令
+func (p *paletteState) filter() {
+	term := strings.TrimSpace(p.input.Value())
+	p.cursor = 0
+	if term == "" {
+		p.matches = make([]fuzzy.Match, len(p.entries))
+		for i, e := range p.entries {
+			p.matches[i] = fuzzy.Match{Str: e.title, Index: i}
+		}
+		return
+	}
+	titles := make([]string, len(p.entries))
+	for i, e := range p.entries {
+		titles[i] = e.title
+	}
+	p.matches = fuzzy.Find(term, titles)
+}
+
+// move 移动光标，到达两端时循环
+func (p *paletteState) move(delta int) {
+	if len(p.matches) == 0 {
+		return
+	}
+	p.cursor = (p.cursor + delta + len(p.matches)) % len(p.matches)
+}
+
+// runPaletteEntry 关闭面板并执行光标所在的命令
+func (m AppModel) runPaletteEntry() (tea.Model, tea.Cmd) {
+	m.state = m.palette.returnState
+	if m.palette.cursor >= len(m.palette.matches) {
+		return m, nil
+	}
+	entry := m.palette.entries[m.palette.matches[m.palette.cursor].Index]
+	return entry.run(m)
+}
+
+// updatePalette 命令面板：输入过滤，方向键选择，enter 执行，esc 或再次按面板键关闭
+func (m AppModel) updatePalette(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Back, m.keys.Palette):
+			m.state = m.palette.returnState
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			return m.runPaletteEntry()
+		case msg.Type == tea.KeyUp || msg.Type == tea.KeyShiftTab:
+			m.palette.move(-1)
+			return m, nil
+		case msg.Type == tea.KeyDown || msg.Type == tea.KeyTab:
+			m.palette.move(1)
+			return m, nil
+		}
+
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.palette.move(-1)
+		case tea.MouseButtonWheelDown:
+			m.palette.move(1)
+		case tea.MouseButtonLeft:
+			// 点击命令直接执行
+			first, rows := m.paletteRows()
+			row := msg.Y - strings.Count(m.renderPaletteHeader(), "\n")
+			if row >= 0 && row < rows && first+row < len(m.palette.matches) {
+				m.palette.cursor = first + row
+				return m.runPaletteEntry()
+			}
+		}
+		return m, nil
+	}
+
+	value := m.palette.input.Value()
+	var cmd tea.Cmd
+	m.palette.input, cmd = m.palette.input.Update(msg)
+	if m.palette.input.Value() != value {
+		m.palette.filter()
+	}
+	return m, cmd
+}
+
+// paletteRows 返回列表中第一条显示的命令与可显示的行数，光标始终可见
+func (m AppModel) paletteRows() (first, rows int) {
+	rows = max(m.height-strings.Count(m.renderPaletteHeader(), "\n")-3, 3)
+	if m.palette.cursor >= rows {
+		first = m.palette.cursor - rows + 1
+	}
+	return first, rows
+}
+
+// renderPaletteHeader 渲染面板标题与输入框
+func (m AppModel) renderPaletteHeader() string {
+	return titleStyle.Render(i18n.T(i18n.PaletteTitle)) + "\n\n" + inputStyle.Render(m.palette.input.View()) + "\n\n"
+}
+
+// renderPalette 渲染命令面板：匹配的字符高亮，快捷键靠右显示
+func (m AppModel) renderPalette() string {
+	var s strings.Builder
+	s.WriteString(m.renderPaletteHeader())
+
+	if len(m.palette.matches) == 0 {
+		s.WriteString(statusStyle.Render(i18n.T(i18n.PaletteNoMatches)))
+	}
+
+	width := max(m.width-6, 20)
+	normal := lipgloss.NewStyle().Foreground(theme.text)
+	selected := lipgloss.NewStyle().Foreground(theme.selected).Bold(true)
+	matched := lipgloss.NewStyle().Foreground(theme.accent).Underline(true)
+	hint := lipgloss.NewStyle().Foreground(theme.muted)
+
+	first, rows := m.paletteRows()
+	for i := first; i < len(m.palette.matches) && i < first+rows; i++ {
+		match := m.palette.matches[i]
+		entry := m.palette.entries[match.Index]
+		style, prefix := normal, "  "
+		if i == m.palette.cursor {
+			style, prefix = selected, "│ "
+		}
+
+		var title strings.Builder
+		highlighted := make(map[int]bool, len(match.MatchedIndexes))
+		for _, index := range match.MatchedIndexes {
+			highlighted[index] = true
+		}
+		for index, r := range entry.title {
+			if highlighted[index] {
+				title.WriteString(matched.Inherit(style).Render(string(r)))
+			} else {
+				title.WriteString(style.Render(string(r)))
+			}
+		}
+
+		line := style.Render(prefix) + title.String()
+		if entry.hint != "" {
+			gap := max(width-lipgloss.Width(line)-lipgloss.Width(entry.hint), 1)
+			line += strings.Repeat(" ", gap) + hint.Render(entry.hint)
+		}
+		s.WriteString("  " + line + "\n")
+	}
+
+	s.WriteString(helpStyle.Render(fmt.Sprintf(i18n.T(i18n.PaletteHelp), m.keys.Enter.Help().Key, m.keys.Back.Help().Key)))
+	return s.String()
+}
+
+// switchLanguage 在中文与英文之间切换界面语言，并保存到配置文件
+func switchLanguage(m AppModel) (tea.Model, tea.Cmd) {
+	lang := i18n.English
+	if i18n.CurrentLanguage() == i18n.English {
+		lang = i18n.Chinese
+	}
+	i18n.SetLanguage(lang)
+	cmd := m.relocalize()
+
+	m.message = i18n.T(i18n.LanguageSwitched)
+	m.isError = false
+	if err := settings.Set("language", string(lang)); err != nil {
+		m.message = err.Error()
+		m.isError = true
+	}
+	return m, cmd
+}
+
+// relocalize 切换语言后重新生成按键帮助、操作菜单与主机列表标题
+func (m *AppModel) relocalize() tea.Cmd {
+	m.keys = getKeys()
+	m.keys.applyTo(&m.hostList)
+	m.keys.applyTo(&m.actionList)
+	m.hostList.AdditionalShortHelpKeys = m.keys.hostListHelp
+	m.actionList.SetItems(newActionItems())
+	return m.refreshHostItems()
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/network"
+	"sshgo/settings"
+	"sshgo/ssh"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// previewMinWidth 显示右侧主机预览的最小终端宽度
+const previewMinWidth = 100
+
+// previewDelay 光标停留多久后开始加载预览，避免快速滚动时频繁执行 ssh -G 与连接检查
+const previewDelay = 200 * time.Millisecond
+
+// previewReachTTL 可达性检查结果的有效期
+const previewReachTTL = time.Minute
+
+// previewState 主机预览：当前预览的主机与已加载的结果
+type previewState struct {
+	alias   string
+	configs map[string]previewConfig
+	reach   map[string]previewReach
+}
+
+// previewConfig ssh -G 的结果与使用的密钥
+type previewConfig struct {
+	options []ssh.ConfigOption
+	err     error
+	key     ssh.KeyInfo
+}
+
+// previewReach 可达性检查结果，done 为 false 表示检查中
+type previewReach struct {
+	rtt     time.Duration
+	err     error
+	checked time.Time
+	done    bool
+}
+
+// previewTickMsg 光标在主机上停留了 previewDelay
+type previewTickMsg struct{ alias string }
+
+// previewConfigMsg ssh -G 执行完成
+type previewConfigMsg struct {
+	alias  string
+	config previewConfig
+}
+
+// previewReachMsg 可达性检查完成
+type previewReachMsg struct {
+	alias string
+	reach previewReach
+}
+
+// newPreviewState 创建空的预览状态
+func newPreviewState() previewState {
+	return previewState{
+		configs: make(map[string]previewConfig),
+		reach:   make(map[string]previewReach),
+	}
+}
+
+// previewVisible 主机列表界面且终端足够宽时显示预览
+func (m AppModel) previewVisible() bool {
+	return m.state == stateHostList && m.width >= previewMinWidth
+}
+
+// hostListWidth 主机列表的宽度：显示预览时占左侧约五分之二
+func (m AppModel) hostListWidth() int {
+	if m.width >= previewMinWidth {
+		return m.width * 2 / 5
+	}
+	return m.width - 4
+}
+
+// selectedHostItem 返回主机列表中光标所在的主机
+func (m AppModel) selectedHostItem() (ssh.SSHHost, bool) {
+	h, ok := m.hostList.SelectedItem().(hostItem)
+	return h.host, ok
+}
+
+// updatePreview 光标移到其他主机时，延迟 previewDelay 后加载该主机的预览
+func (m *AppModel) updatePreview() tea.Cmd {
+	if !m.previewVisible() {
+		return nil
+	}
+	host, ok := m.selectedHostItem()
+	if !ok || host.Host == m.preview.alias {
+		return nil
+	}
+	m.preview.alias = host.Host
+	return tea.Tick(previewDelay, func(time.Time) tea.Msg {
+		return previewTickMsg{alias: host.Host}
+	})
+}
+
+// withPreview 在状态处理之后更新预览
+func withPreview(model tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	m, ok := model.(AppModel)
+	if !ok {
+		return model, cmd
+	}
+	if previewCmd := m.updatePreview(); previewCmd != nil {
+		return m, tea.Batch(cmd, previewCmd)
+	}
+	return m, cmd
+}
+
+// handlePreviewMsg 处理预览的加载与结果
+func (m AppModel) handlePreviewMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case previewTickMsg:
+		host, ok := m.selectedHostItem()
+		if !ok || host.Host != msg.alias || msg.alias != m.preview.alias || host.IsPattern() {
+			return m, nil
+		}
+		var cmds []tea.Cmd
+		if _, ok := m.preview.configs[host.Host]; !ok {
+			cmds = append(cmds, loadEffectiveConfig(host))
+		}
+		if r, ok := m.preview.reach[host.Host]; !ok || (r.done && time.Since(r.checked) > previewReachTTL) {
+			m.preview.reach[host.Host] = previewReach{}
+			cmds = append(cmds, checkPreviewReach(host))
+		}
+		return m, tea.Batch(cmds...)
+	case previewConfigMsg:
+		m.preview.configs[msg.alias] = msg.config
+	case previewReachMsg:
+		m.preview.reach[msg.alias] = msg.reach
+	}
+	return m, nil
+}
+
+// loadEffectiveConfig 在后台执行 ssh -G 并读取使用的密钥：配置的 IdentityFile，否则为 ssh 默认尝试的第一个密钥
+func loadEffectiveConfig(host ssh.SSHHost) tea.Cmd {
+	return func() tea.Msg {
+		options, err := ssh.EffectiveConfig(host)
+		config := previewConfig{options: options, err: err}
+		keyPath := host.KeyFile
+		if keyPath == "" {
+			keyPath = ssh.IdentityFile(options)
+		}
+		if keyPath != "" {
+			config.key = ssh.ReadKeyInfo(keyPath)
+		}
+		return previewConfigMsg{alias: host.Host, config: config}
+	}
+}
+
+// checkPreviewReach 在后台检查主机端口是否可达
+func checkPreviewReach(host ssh.SSHHost) tea.Cmd {
+	return func() tea.Msg {
+		rtt, err := network.CheckReachable(host.Target(), host.Port, settings.Current().Batch.CheckTimeout.Duration)
+		return previewReachMsg{alias: host.Host, reach: previewReach{rtt: rtt, err: err, checked: time.Now(), done: true}}
+	}
+}
+
+// renderPreview 渲染右侧的主机预览：配置、上次连接、可达性、密钥与 ssh -G 的完整配置
+func (m AppModel) renderPreview() string {
+	width := max(m.width-m.hostListWidth()-6, 20)
+	height := max(m.height-2, 5)
+	line := lipgloss.NewStyle().MaxWidth(width)
+	muted := lipgloss.NewStyle().Foreground(theme.muted).MaxWidth(width)
+
+	host, ok := m.selectedHostItem()
+	if !ok {
+		return runPaneStyle.Width(width + 2).Height(height).Render("")
+	}
+
+	var lines []string
+	lines = append(lines, strings.Split(hostDetails(host, m.usage), "\n")...)
+	if m.usage.Get(host.Host).Count == 0 {
+		lines = append(lines, i18n.T(i18n.PreviewNeverConnected))
+	}
+
+	if host.IsPattern() {
+		lines = append(lines, "", i18n.T(i18n.PreviewPattern))
+	} else {
+		switch r, ok := m.preview.reach[host.Host]; {
+		case !ok || !r.done:
+			lines = append(lines, i18n.T(i18n.PreviewChecking))
+		case r.err != nil:
+			lines = append(lines, errorStyle.UnsetMarginLeft().Render(fmt.Sprintf(i18n.T(i18n.PreviewUnreachable), r.err)))
+		default:
+			lines = append(lines, successStyle.UnsetMarginLeft().Render(fmt.Sprintf(i18n.T(i18n.PreviewReachable), r.rtt.Round(time.Millisecond))))
+		}
+	}
+
+	config, loaded := m.preview.configs[host.Host]
+	if key := config.key; key.Path != "" {
+		switch {
+		case !key.Exists:
+			lines = append(lines, warningStyle.UnsetMarginLeft().UnsetBold().Render(fmt.Sprintf(i18n.T(i18n.PreviewKeyMissing), key.Path)))
+		case key.Fingerprint == "":
+			lines = append(lines, fmt.Sprintf(i18n.T(i18n.PreviewKeyNoPublic), key.Path))
+		default:
+			lines = append(lines, fmt.Sprintf(i18n.T(i18n.PreviewKeyInfo), key.Type, key.Fingerprint, key.Path))
+		}
+	}
+
+	for i := range lines {
+		lines[i] = line.Render(lines[i])
+	}
+
+	// ssh -G 的完整配置填满剩余高度
+	if !host.IsPattern() {
+		lines = append(lines, "", diffHeaderStyle.UnsetMarginLeft().MaxWidth(width).Render(i18n.T(i18n.PreviewConfigTitle)))
+		var options []string
+		switch {
+		case !loaded:
+			options = []string{i18n.T(i18n.PreviewLoading)}
+		case config.err != nil:
+			options = []string{config.err.Error()}
+		default:
+			for _, o := range config.options {
+				options = append(options, o.Key+" "+o.Value)
+			}
+		}
+		room := height - len(lines)
+		if len(options) > room {
+			options = append(options[:max(room-1, 0)], fmt.Sprintf(i18n.T(i18n.DiffTruncated), len(options)-max(room-1, 0)))
+		}
+		for _, o := range options {
+			lines = append(lines, muted.Render(o))
+		}
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+
+	return runPaneStyle.Width(width + 2).Height(height).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"sshgo/i18n"
+	"sshgo/recording"
+	"sshgo/transfer"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ActionConnectRecord 连接并录制会话
+const ActionConnectRecord ActionType = "connect_record"
+
+// ActionRecordings 查看会话录制
+const ActionRecordings ActionType = "recordings"
+
+// defaultSpeedIndex 默认回放速度（1x）在 recording.Speeds 中的位置
+const defaultSpeedIndex = 2
+
+// recordingItem 会话录制列表项
+type recordingItem struct {
+	rec recording.Recording
+}
+
+func (i recordingItem) Title() string {
+	return fmt.Sprintf("%s  %s", i.rec.Host, i.rec.Time.Format("2006-01-02 15:04:05"))
+}
+
+func (i recordingItem) Description() string {
+	return fmt.Sprintf("%s  %s", transfer.FormatSize(i.rec.Size), i.rec.Path)
+}
+
+func (i recordingItem) FilterValue() string {
+	return i.rec.Host + " " + i.rec.Time.Format("2006-01-02")
+}
+
+// recordingsState 录制列表状态
+type recordingsState struct {
+	list        list.Model
+	host        string
+	speedIndex  int
+	returnState appState
+}
+
+// playbackDoneMsg 回放结束
+type playbackDoneMsg struct {
+	err error
+}
+
+// playCommand 在 tea.Exec 中回放录制文件
+type playCommand struct {
+	path  string
+	speed float64
+}
+
+func (c playCommand) Run() error          { return recording.Play(c.path, c.speed) }
+func (c playCommand) SetStdin(io.Reader)  {}
+func (c playCommand) SetStdout(io.Writer) {}
+func (c playCommand) SetStderr(io.Writer) {}
+
+func init() {
+	registerCommands(paletteCommand{
+		title:   i18n.CommandOpenRecordings,
+		binding: func(k keyMap) key.Binding { return k.Recordings },
+		run:     func(m AppModel) (tea.Model, tea.Cmd) { return m.openRecordings("") },
+	})
+}
+
+// openRecordings 打开会话录制界面；host 非空时只显示该主机的录制
+func (m AppModel) openRecordings(host string) (tea.Model, tea.Cmd) {
+	recs, err := recording.List(host)
+	if err != nil {
+		m.message = fmt.Sprintf(i18n.T(i18n.LoadRecordingsFailed), err)
+		m.isError = true
+		return m, nil
+	}
+	if len(recs) == 0 {
+		m.message = i18n.T(i18n.NoRecordingsFound)
+		m.isError = true
+		return m, nil
+	}
+
+	items := make([]list.Item, len(recs))
+	for i, r := range recs {
+		items[i] = recordingItem{rec: r}
+	}
+
+	keys := m.keys
+	delegate := newListDelegate()
+	delegate.ShowDescription = true
+	l := list.New(items, delegate, m.width-4, max(m.height, 5))
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(true)
+	l.SetShowHelp(true)
+	l.DisableQuitKeybindings()
+	keys.applyTo(&l)
+	styleList(&l)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.Play, keys.Speed}
+	}
+
+	m.recordings = recordingsState{
+		list:        l,
+		host:        host,
+		speedIndex:  defaultSpeedIndex,
+		returnState: m.state,
+	}
+	m.recordings.updateTitle()
+	m.state = stateRecordings
+	m.message = ""
+	return m, nil
+}
+
+// updateTitle 标题中显示当前回放速度
+func (r *recordingsState) updateTitle() {
+	speed := strconv.FormatFloat(recording.Speeds[r.speedIndex], 'g', -1, 64)
+	r.list.Title = fmt.Sprintf(i18n.T(i18n.RecordingsTitle), speed)
+	if r.host != "" {
+		r.list.Title = fmt.Sprintf(i18n.T(i18n.HostRecordingsTitle), r.host, speed)
+	}
+}
+
+// updateRecordings 更新会话录制状态
+func (m AppModel) updateRecordings(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case playbackDoneMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf(i18n.T(i18n.PlaybackFailed), msg.err)
+			m.isError = true
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.recordings.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keys.Back, m.keys.Quit):
+			m.state = m.recordings.returnState
+			return m, nil
+		case key.Matches(msg, m.keys.SpeedUp):
+			m.recordings.speedIndex = min(m.recordings.speedIndex+1, len(recording.Speeds)-1)
+			m.recordings.updateTitle()
+			return m, nil
+		case key.Matches(msg, m.keys.SpeedDown):
+			m.recordings.speedIndex = max(m.recordings.speedIndex-1, 0)
+			m.recordings.updateTitle()
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			item, ok := m.recordings.list.SelectedItem().(recordingItem)
+			if !ok {
+				return m, nil
+			}
+			m.message = ""
+			play := playCommand{path: item.rec.Path, speed: recording.Speeds[m.recordings.speedIndex]}
+			return m, tea.Exec(play, func(err error) tea.Msg {
+				return playbackDoneMsg{err: err}
+			})
+		}
+	}
+
+	var cmd tea.Cmd
+	m.recordings.list, cmd = m.recordings.list.Update(msg)
+	return m, cmd
+}
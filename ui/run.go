@@ -0,0 +1,361 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"sshgo/i18n"
+	"sshgo/operations"
+	"sshgo/settings"
+	"sshgo/ssh"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ActionBatchRun 在已选择的主机上执行命令
+const ActionBatchRun ActionType = "batch_run"
+
+// maxRunOutput 每个主机保留的最大输出字节数，超出时丢弃最早的输出
+const maxRunOutput = 1 << 20
+
+// runState 并发执行远程命令的进度
+type runState struct {
+	command   string
+	hosts     []ssh.SSHHost
+	results   map[string]*operations.RunResult
+	running   map[string]bool
+	cursor    int
+	cancel    context.CancelFunc
+	finished  bool
+	cancelled bool
+}
+
+// runEventMsg 远程命令事件消息
+type runEventMsg struct {
+	event operations.RunEvent
+	ch    <-chan operations.RunEvent
+}
+
+// runDoneMsg 所有主机执行完毕
+type runDoneMsg struct{}
+
+// openRunInput 打开远程命令输入框
+func (m AppModel) openRunInput() (tea.Model, tea.Cmd) {
+	m.textInput.SetValue(m.run.command)
+	m.textInput.Placeholder = "uptime"
+	m.textInput.Focus()
+	m.state = stateRunInput
+	return m, textinput.Blink
+}
+
+// updateRunInput 更新远程命令输入状态
+func (m AppModel) updateRunInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.Enter):
+			command := strings.TrimSpace(m.textInput.Value())
+			if command == "" {
+				command = m.textInput.Placeholder
+			}
+			return m.startRun(command)
+		case key.Matches(msg, m.keys.Back):
+			m.message = i18n.T(i18n.CancelOperation)
+			m.isError = false
+			m.state = stateBatchMenu
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// startRun 在所有已选择的主机上并发执行命令
+func (m AppModel) startRun(command string) (tea.Model, tea.Cmd) {
+	hosts := m.markedHosts()
+	results := make(map[string]*operations.RunResult, len(hosts))
+	for _, h := range hosts {
+		results[h.Host] = &operations.RunResult{Host: h}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.run = runState{
+		command: command,
+		hosts:   hosts,
+		results: results,
+		running: make(map[string]bool),
+		cancel:  cancel,
+	}
+	m.state = stateRun
+	m.message = ""
+	m.isError = false
+
+	ch := operations.RunOnHosts(ctx, hosts, command, settings.Current().Batch.RunWorkers)
+	return m, waitForRunEvent(ch)
+}
+
+// waitForRunEvent 等待下一个远程命令事件
+func waitForRunEvent(ch <-chan operations.RunEvent) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-ch
+		if !ok {
+			return runDoneMsg{}
+		}
+		return runEventMsg{event: event, ch: ch}
+	}
+}
+
+// applyRunEvent 将事件合并到执行结果中
+func (r *runState) applyRunEvent(e operations.RunEvent) {
+	result, ok := r.results[e.Alias]
+	if !ok {
+		return
+	}
+	switch {
+	case e.Started:
+		r.running[e.Alias] = true
+	case e.Done:
+		delete(r.running, e.Alias)
+		result.Done = true
+		result.ExitCode = e.ExitCode
+		result.Err = e.Err
+	default:
+		result.Output += e.Output
+		if len(result.Output) > maxRunOutput {
+			result.Output = trimRunOutput(result.Output, maxRunOutput)
+		}
+	}
+}
+
+// trimRunOutput 丢弃最早的输出，只保留最后 limit 字节以内的内容：
+// 从下一个完整行开始保留；最后 limit 字节中没有换行时从下一个完整字符开始，避免截断多字节字符
+func trimRunOutput(output string, limit int) string {
+	cut := len(output) - limit
+	if output[cut-1] == '\n' {
+		return output[cut:]
+	}
+	if i := strings.IndexByte(output[cut:], '\n'); i >= 0 {
+		return output[cut+i+1:]
+	}
+	for cut < len(output) && !utf8.RuneStart(output[cut]) {
+		cut++
+	}
+	return output[cut:]
+}
+
+// stop 取消仍在执行的命令
+func (r *runState) stop() {
+	if r.cancel != nil && !r.finished {
+		r.cancel()
+		r.cancelled = true
+	}
+}
+
+// orderedResults 按主机顺序返回执行结果
+func (r runState) orderedResults() []operations.RunResult {
+	results := make([]operations.RunResult, 0, len(r.hosts))
+	for _, h := range r.hosts {
+		results = append(results, *r.results[h.Host])
+	}
+	return results
+}
+
+// updateRun 更新远程命令执行视图
+func (m AppModel) updateRun(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case runEventMsg:
+		m.run.applyRunEvent(msg.event)
+		return m, waitForRunEvent(msg.ch)
+	case runDoneMsg:
+		m.run.finished = true
+		if m.run.cancel != nil {
+			m.run.cancel()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Up):
+			if m.run.cursor > 0 {
+				m.run.cursor--
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.run.cursor < len(m.run.hosts)-1 {
+				m.run.cursor++
+			}
+		case key.Matches(msg, m.keys.Save):
+			m.textInput.SetValue("")
+			m.textInput.Placeholder = "sshgo-run.log"
+			m.textInput.Focus()
+			m.state = stateRunSave
+			return m, textinput.Blink
+		case key.Matches(msg, m.keys.Back, m.keys.Quit):
+			if !m.run.finished {
+				m.run.stop()
+				return m, nil
+			}
+			m.state = stateBatchMenu
+		}
+	}
+	return m, nil
+}
+
+// updateRunSave 更新保存输出的输入状态
+func (m AppModel) updateRunSave(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// 保存期间命令可能仍在执行，继续处理事件
+	switch msg := msg.(type) {
+	case runEventMsg, runDoneMsg:
+		return m.updateRun(msg)
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Enter):
+			path := strings.TrimSpace(m.textInput.Value())
+			if path == "" {
+				path = m.textInput.Placeholder
+			}
+			if err := operations.SaveRunOutput(path, m.run.command, m.run.orderedResults()); err != nil {
+				m.message = err.Error()
+				m.isError = true
+				return m, nil
+			}
+			m.message = fmt.Sprintf(i18n.T(i18n.SuccessfullySavedRunOutput), len(m.run.hosts), path)
+			m.isError = false
+			m.state = stateRun
+			return m, nil
+		case key.Matches(msg, m.keys.Back):
+			m.message = i18n.T(i18n.CancelOperation)
+			m.isError = false
+			m.state = stateRun
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// runStatus 返回主机的状态图标与文本
+func (m AppModel) runStatus(h ssh.SSHHost) (string, lipgloss.Style) {
+	r := m.run.results[h.Host]
+	switch {
+	case r.Done && errors.Is(r.Err, context.Canceled):
+		return "■ " + i18n.T(i18n.RunStatusCancelled), warningStyle
+	case r.Done && r.Err != nil:
+		return "✗ " + i18n.T(i18n.RunStatusError), errorStyle
+	case r.Done && r.ExitCode != 0:
+		return "✗ " + fmt.Sprintf(i18n.T(i18n.RunStatusExit), r.ExitCode), errorStyle
+	case r.Done:
+		return "✓ " + i18n.T(i18n.RunStatusOK), successStyle
+	case m.run.running[h.Host]:
+		return "⟳ " + i18n.T(i18n.RunStatusRunning), statusStyle
+	default:
+		return "· " + i18n.T(i18n.RunStatusPending), statusStyle
+	}
+}
+
+// renderRun 渲染远程命令执行视图：左侧为主机状态，右侧为选中主机的输出
+func (m AppModel) renderRun() string {
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T(i18n.RunTitle), len(m.run.hosts), m.run.command)))
+	s.WriteString("\n\n")
+
+	paneHeight := max(m.height-9, 5)
+	leftWidth := min(max(m.width/3, 24), 40)
+	rightWidth := max(m.width-leftWidth-10, 20)
+
+	// 左侧主机列表，保证光标所在行可见
+	var left []string
+	start := 0
+	if m.run.cursor >= paneHeight {
+		start = m.run.cursor - paneHeight + 1
+	}
+	ok, failed, running, pending := 0, 0, 0, 0
+	for i, h := range m.run.hosts {
+		status, style := m.runStatus(h)
+		r := m.run.results[h.Host]
+		switch {
+		case r.OK():
+			ok++
+		case r.Done:
+			failed++
+		case m.run.running[h.Host]:
+			running++
+		default:
+			pending++
+		}
+		if i < start || i >= start+paneHeight {
+			continue
+		}
+		cursor := "  "
+		if i == m.run.cursor {
+			cursor = "> "
+		}
+		line := lipgloss.NewStyle().MaxWidth(leftWidth).Render(cursor + h.Host + "  " + style.UnsetMarginLeft().Render(status))
+		left = append(left, line)
+	}
+
+	// 右侧输出，只显示最后若干行
+	var output string
+	if len(m.run.hosts) > 0 {
+		h := m.run.hosts[m.run.cursor]
+		output = m.run.results[h.Host].Output
+		if err := m.run.results[h.Host].Err; err != nil && !errors.Is(err, context.Canceled) {
+			output += err.Error()
+		}
+	}
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(output, "\r", ""), "\n"), "\n")
+	if output == "" {
+		lines = []string{statusStyle.UnsetMarginLeft().Render(i18n.T(i18n.RunNoOutput))}
+	}
+	if len(lines) > paneHeight {
+		lines = lines[len(lines)-paneHeight:]
+	}
+	lineStyle := lipgloss.NewStyle().MaxWidth(rightWidth)
+	for i, line := range lines {
+		lines[i] = lineStyle.Render(line)
+	}
+
+	leftPane := runPaneStyle.Width(leftWidth).Height(paneHeight).Render(strings.Join(left, "\n"))
+	rightPane := runPaneStyle.Width(rightWidth).Height(paneHeight).Render(strings.Join(lines, "\n"))
+	s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, leftPane, rightPane))
+	s.WriteString("\n")
+
+	s.WriteString(statusStyle.Render(fmt.Sprintf(i18n.T(i18n.RunSummary), ok, failed, running, pending)))
+	help := i18n.T(i18n.RunHelpRunning)
+	if m.run.finished {
+		help = i18n.T(i18n.RunHelpDone)
+	}
+	help = fmt.Sprintf(help, m.keys.Up.Help().Key+"/"+m.keys.Down.Help().Key, m.keys.Save.Help().Key, m.keys.Back.Help().Key)
+	s.WriteString("\n")
+	s.WriteString(helpStyle.Render(help))
+	return s.String()
+}
+
+// renderRunInput 渲染远程命令输入框
+func (m AppModel) renderRunInput() string {
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T(i18n.EnterRunCommand), len(m.markedHosts()))))
+	s.WriteString("\n\n")
+	s.WriteString(inputStyle.Render(m.textInput.View()))
+	s.WriteString("\n\n")
+	s.WriteString(helpStyle.Render(m.keys.inputHelp()))
+	return s.String()
+}
+
+// renderRunSave 渲染保存输出的输入框
+func (m AppModel) renderRunSave() string {
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T(i18n.EnterRunOutputPath), len(m.run.hosts))))
+	s.WriteString("\n\n")
+	s.WriteString(inputStyle.Render(m.textInput.View()))
+	s.WriteString("\n\n")
+	s.WriteString(helpStyle.Render(m.keys.inputHelp()))
+	return s.String()
+}
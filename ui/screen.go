@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"sshgo/i18n"
+	"sshgo/operations"
+	"sshgo/settings"
+	"sshgo/ssh"
+	"sshgo/transfer"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// screen 压入主界面的子界面（网络诊断、SFTP 文件浏览），关闭时返回 closeScreen
+type screen interface {
+	tea.Model
+	// release 子界面关闭或程序退出时释放占用的资源
+	release()
+}
+
+// screenClosedMsg 栈顶的子界面已关闭
+type screenClosedMsg struct{}
+
+// closeScreen 关闭当前子界面，回到打开它的界面
+func closeScreen() tea.Msg {
+	return screenClosedMsg{}
+}
+
+// pushScreen 压入子界面，并按当前窗口尺寸初始化
+func (m AppModel) pushScreen(s screen) (tea.Model, tea.Cmd) {
+	model, cmd := s.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	m.screens = append(m.screens[:len(m.screens):len(m.screens)], model.(screen))
+	m.message = ""
+	return m, tea.Batch(model.Init(), cmd)
+}
+
+// popScreen 关闭栈顶的子界面
+func (m AppModel) popScreen() AppModel {
+	top := len(m.screens) - 1
+	m.screens[top].release()
+	m.screens = m.screens[:top]
+	return m
+}
+
+// updateScreen 将消息交给栈顶的子界面处理
+func (m AppModel) updateScreen(msg tea.Msg) (tea.Model, tea.Cmd) {
+	top := len(m.screens) - 1
+	model, cmd := m.screens[top].Update(msg)
+	m.screens = append(m.screens[:top:top], model.(screen))
+	return m, cmd
+}
+
+// releaseScreens 程序退出时释放所有子界面
+func (m AppModel) releaseScreens() {
+	for i := len(m.screens) - 1; i >= 0; i-- {
+		m.screens[i].release()
+	}
+}
+
+// connectDoneMsg ssh 连接结束
+type connectDoneMsg struct {
+	host string
+	err  error
+}
+
+// recordCommand 在 tea.Exec 中连接并录制会话
+type recordCommand struct {
+	host ssh.SSHHost
+}
+
+func (c recordCommand) Run() error          { return operations.ConnectAndRecord(c.host) }
+func (c recordCommand) SetStdin(io.Reader)  {}
+func (c recordCommand) SetStdout(io.Writer) {}
+func (c recordCommand) SetStderr(io.Writer) {}
+
+// connect 连接主机：在 tmux/screen 新窗口中打开时界面保持不变；
+// 否则暂停界面，将终端交给 ssh，连接结束后回到主机列表（过滤、滚动位置和选中项保持不变）
+func (m AppModel) connect(host ssh.SSHHost, record bool) (tea.Model, tea.Cmd) {
+	m.state = stateHostList
+	m.message = ""
+	m.isError = false
+
+	if !record && operations.LaunchesInMultiplexer() {
+		if err := operations.ConnectToHost(host); err != nil {
+			m.message = err.Error()
+			m.isError = true
+			return m, nil
+		}
+		m.message = fmt.Sprintf(i18n.T(i18n.OpenedInNewWindow), host.Host)
+		cmd := m.reloadUsage()
+		return m, cmd
+	}
+
+	if record || settings.Current().Connect.Record {
+		return m, tea.Exec(recordCommand{host: host}, func(err error) tea.Msg {
+			return connectDoneMsg{host: host.Host, err: err}
+		})
+	}
+
+	conn, err := operations.PrepareConnection(host)
+	if err != nil {
+		m.message = err.Error()
+		m.isError = true
+		return m, nil
+	}
+	return m, tea.ExecProcess(conn.Cmd, func(err error) tea.Msg {
+		return connectDoneMsg{host: conn.Host.Host, err: conn.Finish(err)}
+	})
+}
+
+// finishConnect 连接结束：显示错误，并重新读取连接后更新的使用记录
+func (m AppModel) finishConnect(msg connectDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.message = fmt.Sprintf(i18n.T(i18n.ConnectionEnded), msg.host, msg.err)
+		m.isError = true
+	}
+	cmd := m.reloadUsage()
+	return m, cmd
+}
+
+// sftpOpenedMsg SFTP 会话已建立
+type sftpOpenedMsg struct {
+	session *transfer.Session
+	err     error
+}
+
+// sftpOpenCommand 在 tea.Exec 中建立 SFTP 会话，便于 ssh 在终端中提示输入密码或确认主机指纹
+type sftpOpenCommand struct {
+	host    ssh.SSHHost
+	session *transfer.Session
+}
+
+func (c *sftpOpenCommand) Run() error {
+	fmt.Println(i18n.TWithArgs(i18n.SFTPConnecting, c.host.Host))
+	session, err := transfer.Open(c.host)
+	c.session = session
+	return err
+}
+func (c *sftpOpenCommand) SetStdin(io.Reader)  {}
+func (c *sftpOpenCommand) SetStdout(io.Writer) {}
+func (c *sftpOpenCommand) SetStderr(io.Writer) {}
+
+// openSFTP 建立 SFTP 会话，成功后打开文件浏览界面
+func (m AppModel) openSFTP(host ssh.SSHHost) (tea.Model, tea.Cmd) {
+	open := &sftpOpenCommand{host: host}
+	return m, tea.Exec(open, func(err error) tea.Msg {
+		return sftpOpenedMsg{session: open.session, err: err}
+	})
+}
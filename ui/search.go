@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"sync"
+
+	"sshgo/ssh"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// hostIndex 按过滤文本查找列表项对应的主机，供主机列表的搜索使用
+// 列表在后台执行过滤，因此需要加锁
+type hostIndex struct {
+	mu    sync.RWMutex
+	hosts map[string]ssh.SSHHost
+}
+
+// set 用当前的列表项重建索引
+func (x *hostIndex) set(items []list.Item) {
+	hosts := make(map[string]ssh.SSHHost, len(items))
+	for _, item := range items {
+		if h, ok := item.(hostItem); ok {
+			hosts[h.FilterValue()] = h.host
+		}
+	}
+	x.mu.Lock()
+	x.hosts = hosts
+	x.mu.Unlock()
+}
+
+// filter 主机列表的过滤函数：支持 user:、port:、tag:、group:、key:、via:、host: 字段条件与模糊匹配，
+// 自由文本匹配到的字符在列表中高亮
+func (x *hostIndex) filter(term string, targets []string) []list.Rank {
+	hosts := make([]*ssh.SSHHost, len(targets))
+	x.mu.RLock()
+	for i, target := range targets {
+		if h, ok := x.hosts[target]; ok {
+			hosts[i] = &h
+		}
+	}
+	x.mu.RUnlock()
+
+	matches := ssh.ParseQuery(term).Search(targets, hosts)
+	ranks := make([]list.Rank, len(matches))
+	for i, m := range matches {
+		ranks[i] = list.Rank{Index: m.Index, MatchedIndexes: m.MatchedIndexes}
+	}
+	return ranks
+}
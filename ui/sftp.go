@@ -0,0 +1,411 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sshgo/i18n"
+	"sshgo/transfer"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ActionSFTP 打开 SFTP 文件浏览器
+const ActionSFTP ActionType = "sftp"
+
+// progressBarWidth 传输进度条宽度
+const progressBarWidth = 30
+
+// sftpPane 文件浏览面板（本地或远程）
+type sftpPane struct {
+	dir     string
+	entries []transfer.Entry
+	cursor  int
+	err     error
+}
+
+// selected 返回光标所在的目录项
+func (p sftpPane) selected() (transfer.Entry, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.entries) {
+		return transfer.Entry{}, false
+	}
+	return p.entries[p.cursor], true
+}
+
+// move 移动光标
+func (p *sftpPane) move(delta int) {
+	p.cursor = min(max(p.cursor+delta, 0), max(len(p.entries)-1, 0))
+}
+
+// sftpJob 一次传输任务，失败后可以断点续传
+type sftpJob struct {
+	upload bool
+	src    string
+	dstDir string
+}
+
+// sftpListMsg 远程目录列表消息
+type sftpListMsg struct {
+	dir     string
+	entries []transfer.Entry
+	err     error
+}
+
+// sftpTransferMsg 传输进度或完成消息
+type sftpTransferMsg struct {
+	progress transfer.Progress
+	done     bool
+	err      error
+	ch       <-chan sftpTransferMsg
+}
+
+// SFTPModel SFTP 双栏文件浏览模型
+type SFTPModel struct {
+	session      *transfer.Session
+	local        sftpPane
+	remote       sftpPane
+	remoteActive bool
+
+	// 传输状态
+	transferring bool
+	progress     transfer.Progress
+	cancel       context.CancelFunc
+	lastJob      *sftpJob
+	canResume    bool
+
+	message string
+	isError bool
+	keys    keyMap
+
+	width  int
+	height int
+}
+
+// NewSFTPModel 创建 SFTP 文件浏览模型
+func NewSFTPModel(session *transfer.Session) SFTPModel {
+	applyTheme()
+	m := SFTPModel{session: session, keys: getKeys(), width: 80, height: 24}
+
+	if wd, err := os.Getwd(); err == nil {
+		m.local.dir = wd
+	} else {
+		m.local.dir = "."
+	}
+	m.local.entries, m.local.err = transfer.ReadLocalDir(m.local.dir)
+
+	if wd, err := session.Getwd(); err == nil {
+		m.remote.dir = wd
+	} else {
+		m.remote.dir = "."
+	}
+	return m
+}
+
+// Init 初始化：加载远程目录
+func (m SFTPModel) Init() tea.Cmd {
+	return m.listRemote(m.remote.dir)
+}
+
+// listRemote 异步列出远程目录
+func (m SFTPModel) listRemote(dir string) tea.Cmd {
+	session := m.session
+	return func() tea.Msg {
+		entries, err := session.ReadDir(dir)
+		return sftpListMsg{dir: dir, entries: entries, err: err}
+	}
+}
+
+// openLocal 进入本地目录
+func (m *SFTPModel) openLocal(dir string) {
+	entries, err := transfer.ReadLocalDir(dir)
+	if err != nil {
+		m.message = err.Error()
+		m.isError = true
+		return
+	}
+	m.local = sftpPane{dir: dir, entries: entries}
+}
+
+// activePane 返回当前活动面板
+func (m *SFTPModel) activePane() *sftpPane {
+	if m.remoteActive {
+		return &m.remote
+	}
+	return &m.local
+}
+
+// Update 更新
+func (m SFTPModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case sftpListMsg:
+		if msg.err != nil {
+			m.message = msg.err.Error()
+			m.isError = true
+			return m, nil
+		}
+		m.remote = sftpPane{dir: msg.dir, entries: msg.entries}
+		return m, nil
+
+	case sftpTransferMsg:
+		m.progress = msg.progress
+		if !msg.done {
+			return m, waitForTransfer(msg.ch)
+		}
+		return m.finishTransfer(msg.err)
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey 处理按键
+func (m SFTPModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.transferring {
+		if key.Matches(msg, m.keys.Back) {
+			m.cancel()
+		}
+		return m, nil
+	}
+
+	// 进入目录/返回上级优先于翻页（默认方案中 h、l 等同时用于翻页）
+	pane := m.activePane()
+	switch {
+	case key.Matches(msg, m.keys.Quit, m.keys.Back):
+		return m, closeScreen
+	case key.Matches(msg, m.keys.SwitchPane):
+		m.remoteActive = !m.remoteActive
+	case key.Matches(msg, m.keys.Up):
+		pane.move(-1)
+	case key.Matches(msg, m.keys.Down):
+		pane.move(1)
+	case key.Matches(msg, m.keys.Open):
+		entry, ok := pane.selected()
+		if !ok || !entry.IsDir {
+			return m, nil
+		}
+		if m.remoteActive {
+			return m, m.listRemote(transfer.RemoteJoin(m.remote.dir, entry.Name))
+		}
+		m.openLocal(filepath.Join(m.local.dir, entry.Name))
+	case key.Matches(msg, m.keys.Parent):
+		if m.remoteActive {
+			return m, m.listRemote(transfer.RemoteParent(m.remote.dir))
+		}
+		m.openLocal(transfer.LocalParent(m.local.dir))
+	case key.Matches(msg, m.keys.PageUp):
+		pane.move(-10)
+	case key.Matches(msg, m.keys.PageDown):
+		pane.move(10)
+	case key.Matches(msg, m.keys.Copy):
+		entry, ok := pane.selected()
+		if !ok {
+			return m, nil
+		}
+		job := &sftpJob{upload: !m.remoteActive}
+		if job.upload {
+			job.src, job.dstDir = filepath.Join(m.local.dir, entry.Name), m.remote.dir
+		} else {
+			job.src, job.dstDir = transfer.RemoteJoin(m.remote.dir, entry.Name), m.local.dir
+		}
+		return m.startTransfer(job, false)
+	case key.Matches(msg, m.keys.Resume):
+		if m.lastJob == nil || !m.canResume {
+			m.message = i18n.T(i18n.SFTPNothingToResume)
+			m.isError = true
+			return m, nil
+		}
+		return m.startTransfer(m.lastJob, true)
+	}
+	return m, nil
+}
+
+// startTransfer 在后台开始传输，resume 为 true 时断点续传
+func (m SFTPModel) startTransfer(job *sftpJob, resume bool) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.transferring = true
+	m.lastJob = job
+	m.canResume = false
+	m.progress = transfer.Progress{}
+	m.message = ""
+	m.isError = false
+
+	ch := make(chan sftpTransferMsg, 1)
+	session := m.session
+	go func() {
+		var last transfer.Progress
+		opts := transfer.Options{
+			Resume: resume,
+			// 进度消息只保留最新一条，界面处理不过来时丢弃中间进度
+			Progress: func(p transfer.Progress) {
+				last = p
+				select {
+				case ch <- sftpTransferMsg{progress: p, ch: ch}:
+				default:
+				}
+			},
+		}
+		var err error
+		if job.upload {
+			err = session.Upload(ctx, job.src, job.dstDir, opts)
+		} else {
+			err = session.Download(ctx, job.src, job.dstDir, opts)
+		}
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		ch <- sftpTransferMsg{progress: last, done: true, err: err, ch: ch}
+	}()
+
+	return m, waitForTransfer(ch)
+}
+
+// waitForTransfer 等待下一个传输消息
+func waitForTransfer(ch <-chan sftpTransferMsg) tea.Cmd {
+	return func() tea.Msg {
+		return <-ch
+	}
+}
+
+// finishTransfer 处理传输结束：刷新目标目录并显示结果
+func (m SFTPModel) finishTransfer(err error) (tea.Model, tea.Cmd) {
+	m.transferring = false
+	m.cancel()
+	job := m.lastJob
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		m.message = fmt.Sprintf(i18n.T(i18n.SFTPTransferCancelled), m.keys.Resume.Help().Key)
+		m.isError = true
+		m.canResume = true
+	case err != nil:
+		m.message = fmt.Sprintf(i18n.T(i18n.SFTPTransferRetryHint), err, m.keys.Resume.Help().Key)
+		m.isError = true
+		m.canResume = true
+	case job.upload:
+		m.message = fmt.Sprintf(i18n.T(i18n.SFTPUploaded), job.src, job.dstDir)
+		m.isError = false
+	default:
+		m.message = fmt.Sprintf(i18n.T(i18n.SFTPDownloaded), job.src, job.dstDir)
+		m.isError = false
+	}
+
+	// 刷新目标面板（部分传输的文件也会显示出来）
+	if job.upload {
+		if job.dstDir == m.remote.dir {
+			return m, m.listRemote(m.remote.dir)
+		}
+	} else if job.dstDir == m.local.dir {
+		cursor := m.local.cursor
+		m.openLocal(m.local.dir)
+		m.local.move(cursor)
+	}
+	return m, nil
+}
+
+// View 渲染
+func (m SFTPModel) View() string {
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf(i18n.T(i18n.SFTPTitle), m.session.Host.Host)))
+	s.WriteString("\n\n")
+
+	paneHeight := max(m.height-10, 5)
+	paneWidth := max((m.width-10)/2, 20)
+	localTitle := fmt.Sprintf(i18n.T(i18n.SFTPLocalPane), m.local.dir)
+	remoteTitle := fmt.Sprintf(i18n.T(i18n.SFTPRemotePane), m.session.Host.Host, m.remote.dir)
+	left := renderSFTPPane(localTitle, m.local, !m.remoteActive, paneWidth, paneHeight)
+	right := renderSFTPPane(remoteTitle, m.remote, m.remoteActive, paneWidth, paneHeight)
+	s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, right))
+	s.WriteString("\n")
+
+	if m.transferring || m.progress.TotalFiles > 0 {
+		s.WriteString(statusStyle.Render(renderProgress(m.progress)))
+		s.WriteString("\n")
+	}
+
+	k := m.keys
+	help := fmt.Sprintf(i18n.T(i18n.SFTPHelp), k.SwitchPane.Help().Key, k.Open.Help().Key,
+		k.Parent.Help().Key, k.Copy.Help().Key, k.Resume.Help().Key, k.Quit.Help().Key)
+	if m.transferring {
+		help = fmt.Sprintf(i18n.T(i18n.SFTPHelpTransfer), k.Back.Help().Key)
+	}
+	s.WriteString(helpStyle.Render(help))
+
+	if m.message != "" {
+		s.WriteString("\n\n")
+		if m.isError {
+			s.WriteString(errorStyle.Render("✗ " + m.message))
+		} else {
+			s.WriteString(successStyle.Render("✓ " + m.message))
+		}
+	}
+	return s.String()
+}
+
+// renderSFTPPane 渲染一个文件面板
+func renderSFTPPane(title string, p sftpPane, active bool, width, height int) string {
+	lineStyle := lipgloss.NewStyle().MaxWidth(width)
+	lines := []string{lineStyle.Render(title), ""}
+
+	rows := height - len(lines)
+	start := 0
+	if p.cursor >= rows {
+		start = p.cursor - rows + 1
+	}
+	if p.err != nil {
+		lines = append(lines, errorStyle.UnsetMarginLeft().Render(p.err.Error()))
+	} else if len(p.entries) == 0 {
+		lines = append(lines, statusStyle.UnsetMarginLeft().Render(i18n.T(i18n.SFTPEmptyDir)))
+	}
+	for i := start; i < len(p.entries) && i < start+rows; i++ {
+		e := p.entries[i]
+		name, size := e.Name, transfer.FormatSize(e.Size)
+		if e.IsDir {
+			name, size = e.Name+"/", ""
+		}
+		cursor := "  "
+		if i == p.cursor && active {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%s%-*s %10s", cursor, max(width-14, 8), name, size)
+		if i == p.cursor && active {
+			line = titleStyle.UnsetMarginLeft().Render(line)
+		}
+		lines = append(lines, lineStyle.Render(line))
+	}
+
+	style := runPaneStyle.Width(width).Height(height)
+	if active {
+		style = style.BorderForeground(theme.title)
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// renderProgress 渲染传输进度条
+func renderProgress(p transfer.Progress) string {
+	percent := p.Percent()
+	filled := min(max(int(percent*progressBarWidth), 0), progressBarWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+	return fmt.Sprintf("%s %3.0f%%  %s / %s  (%d/%d)  %s", bar, percent*100,
+		transfer.FormatSize(p.Done), transfer.FormatSize(p.Total), p.Files, p.TotalFiles, p.File)
+}
+
+// release 关闭文件浏览界面时取消进行中的传输并关闭 SFTP 会话
+func (m SFTPModel) release() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.session.Close()
+}
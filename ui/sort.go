@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/ssh"
+	"sshgo/state"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sortMode 主机列表排序方式
+type sortMode string
+
+const (
+	sortFrecency sortMode = "frecency"
+	sortAlpha    sortMode = "alpha"
+	sortRecent   sortMode = "recent"
+	sortFile     sortMode = "file"
+)
+
+// sortModes 按切换顺序排列的排序方式
+var sortModes = []sortMode{sortFrecency, sortAlpha, sortRecent, sortFile}
+
+// parseSortMode 解析保存的排序方式，无法识别时使用文件顺序
+func parseSortMode(s string) sortMode {
+	for _, mode := range sortModes {
+		if string(mode) == s {
+			return mode
+		}
+	}
+	return sortFile
+}
+
+// next 返回下一个排序方式
+func (s sortMode) next() sortMode {
+	for i, mode := range sortModes {
+		if mode == s {
+			return sortModes[(i+1)%len(sortModes)]
+		}
+	}
+	return sortModes[0]
+}
+
+// label 排序方式的显示名称
+func (s sortMode) label() string {
+	switch s {
+	case sortFrecency:
+		return i18n.T(i18n.SortFrecency)
+	case sortAlpha:
+		return i18n.T(i18n.SortAlphabetical)
+	case sortRecent:
+		return i18n.T(i18n.SortRecent)
+	default:
+		return i18n.T(i18n.SortFileOrder)
+	}
+}
+
+// sortHosts 按排序方式返回排序后的主机副本，收藏的主机始终排在最前面
+func sortHosts(hosts []ssh.SSHHost, mode sortMode, usage *state.Usage) []ssh.SSHHost {
+	sorted := append([]ssh.SSHHost(nil), hosts...)
+	now := time.Now()
+
+	less := func(a, b ssh.SSHHost) bool {
+		switch mode {
+		case sortFrecency:
+			fa, fb := usage.Frecency(a.Host, now), usage.Frecency(b.Host, now)
+			if fa != fb {
+				return fa > fb
+			}
+		case sortAlpha:
+			return strings.ToLower(a.Host) < strings.ToLower(b.Host)
+		case sortRecent:
+			la, lb := usage.Get(a.Host).LastUsed, usage.Get(b.Host).LastUsed
+			if !la.Equal(lb) {
+				return la.After(lb)
+			}
+		}
+		return false
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		fi, fj := usage.Get(sorted[i].Host).Favorite, usage.Get(sorted[j].Host).Favorite
+		if fi != fj {
+			return fi
+		}
+		return less(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+func init() {
+	registerCommands(
+		paletteCommand{
+			title:   i18n.CommandCycleSort,
+			binding: func(k keyMap) key.Binding { return k.Sort },
+			run: func(m AppModel) (tea.Model, tea.Cmd) {
+				cmd := m.cycleSort()
+				return m, cmd
+			},
+		},
+		paletteCommand{
+			title:   i18n.CommandToggleFavorite,
+			binding: func(k keyMap) key.Binding { return k.Pin },
+			available: func(m AppModel) bool {
+				_, ok := m.paletteHost()
+				return ok
+			},
+			run: func(m AppModel) (tea.Model, tea.Cmd) {
+				host, _ := m.paletteHost()
+				cmd := m.toggleFavorite(host.Host)
+				return m, cmd
+			},
+		},
+	)
+}
+
+// cycleSort 切换到下一个排序方式并保存，光标回到列表顶部
+func (m *AppModel) cycleSort() tea.Cmd {
+	m.sortMode = m.sortMode.next()
+	m.message = fmt.Sprintf(i18n.T(i18n.SortModeChanged), m.sortMode.label())
+	m.isError = false
+	if usage, err := state.SetSortMode(string(m.sortMode)); err != nil {
+		m.message = fmt.Sprintf(i18n.T(i18n.SaveUsageFailed), err)
+		m.isError = true
+	} else {
+		m.usage = usage
+	}
+	cmd := m.refreshHostItems()
+	m.hostList.Select(0)
+	return cmd
+}
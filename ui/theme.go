@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"os"
+	"sync"
+
+	"sshgo/settings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 界面样式，由 applyTheme 按配置的主题生成
+var (
+	// 标题样式
+	titleStyle lipgloss.Style
+
+	// 状态信息样式
+	statusStyle lipgloss.Style
+
+	// 错误样式
+	errorStyle lipgloss.Style
+
+	// 成功样式
+	successStyle lipgloss.Style
+
+	// 详情框样式
+	detailsBoxStyle lipgloss.Style
+
+	// 帮助样式
+	helpStyle lipgloss.Style
+
+	// 警告样式
+	warningStyle lipgloss.Style
+
+	// 输入框样式
+	inputStyle lipgloss.Style
+
+	// diff 样式
+	diffHeaderStyle  lipgloss.Style
+	diffHunkStyle    lipgloss.Style
+	diffAddStyle     lipgloss.Style
+	diffDelStyle     lipgloss.Style
+	diffContextStyle lipgloss.Style
+
+	// 分栏面板样式
+	runPaneStyle lipgloss.Style
+
+	// 当前主题的颜色，用于列表等组件
+	theme themeColors
+)
+
+// themeColors 主题颜色
+type themeColors struct {
+	title, text, muted, accent, border, selected lipgloss.TerminalColor
+	success, warning, error                      lipgloss.TerminalColor
+	titleBar, titleBarText                       lipgloss.TerminalColor
+}
+
+var themeOnce sync.Once
+
+// color 将配置中的颜色转换为 lipgloss 颜色，为空时使用终端默认颜色
+func color(c string) lipgloss.TerminalColor {
+	if c == "" {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(c)
+}
+
+// applyTheme 按配置的主题生成界面样式（仅首次调用生效）
+// 设置了 NO_COLOR 时不使用任何颜色，也不检测终端背景
+func applyTheme() {
+	themeOnce.Do(func() {
+		var c settings.ThemeColors
+		if os.Getenv("NO_COLOR") == "" {
+			c = settings.Current().Theme.Colors(lipgloss.HasDarkBackground)
+		}
+		setStyles(themeColors{
+			title:        color(c.Title),
+			text:         color(c.Text),
+			muted:        color(c.Muted),
+			accent:       color(c.Accent),
+			border:       color(c.Border),
+			selected:     color(c.Selected),
+			success:      color(c.Success),
+			warning:      color(c.Warning),
+			error:        color(c.Error),
+			titleBar:     color(c.TitleBar),
+			titleBarText: color(c.TitleBarText),
+		})
+	})
+}
+
+// setStyles 按主题颜色生成界面样式
+func setStyles(t themeColors) {
+	theme = t
+
+	titleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.title).
+		MarginLeft(2)
+
+	statusStyle = lipgloss.NewStyle().
+		Foreground(t.muted).
+		MarginLeft(2)
+
+	errorStyle = lipgloss.NewStyle().
+		Foreground(t.error).
+		Bold(true).
+		MarginLeft(2)
+
+	successStyle = lipgloss.NewStyle().
+		Foreground(t.success).
+		MarginLeft(2)
+
+	detailsBoxStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.border).
+		Padding(1, 2).
+		MarginLeft(2).
+		MarginTop(1)
+
+	helpStyle = lipgloss.NewStyle().
+		Foreground(t.muted).
+		MarginLeft(2).
+		MarginTop(1)
+
+	warningStyle = lipgloss.NewStyle().
+		Foreground(t.warning).
+		Bold(true).
+		MarginLeft(2)
+
+	inputStyle = lipgloss.NewStyle().
+		MarginLeft(2)
+
+	diffHeaderStyle = lipgloss.NewStyle().
+		Bold(true).
+		MarginLeft(2)
+	diffHunkStyle = lipgloss.NewStyle().
+		Foreground(t.accent).
+		MarginLeft(2)
+	diffAddStyle = lipgloss.NewStyle().
+		Foreground(t.success).
+		MarginLeft(2)
+	diffDelStyle = lipgloss.NewStyle().
+		Foreground(t.error).
+		MarginLeft(2)
+	diffContextStyle = lipgloss.NewStyle().
+		Foreground(t.muted).
+		MarginLeft(2)
+
+	runPaneStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.border).
+		Padding(0, 1).
+		MarginLeft(2)
+}
+
+// newListDelegate 创建使用当前主题颜色的列表项渲染器
+func newListDelegate() list.DefaultDelegate {
+	d := list.NewDefaultDelegate()
+	d.Styles.NormalTitle = d.Styles.NormalTitle.Foreground(theme.text)
+	d.Styles.NormalDesc = d.Styles.NormalDesc.Foreground(theme.muted)
+	d.Styles.SelectedTitle = d.Styles.SelectedTitle.Foreground(theme.selected).BorderForeground(theme.selected)
+	d.Styles.SelectedDesc = d.Styles.SelectedDesc.Foreground(theme.selected).BorderForeground(theme.selected)
+	d.Styles.DimmedTitle = d.Styles.DimmedTitle.Foreground(theme.muted)
+	d.Styles.DimmedDesc = d.Styles.DimmedDesc.Foreground(theme.muted)
+	return d
+}
+
+// styleList 将当前主题应用到列表的标题、状态栏和帮助
+func styleList(l *list.Model) {
+	l.Styles.Title = l.Styles.Title.Background(theme.titleBar).Foreground(theme.titleBarText)
+	l.Styles.StatusBar = l.Styles.StatusBar.Foreground(theme.muted)
+	l.Styles.NoItems = l.Styles.NoItems.Foreground(theme.muted)
+	l.Styles.FilterPrompt = l.Styles.FilterPrompt.Foreground(theme.accent)
+	l.Styles.FilterCursor = l.Styles.FilterCursor.Foreground(theme.selected)
+	l.Help.Styles.ShortKey = l.Help.Styles.ShortKey.Foreground(theme.muted)
+	l.Help.Styles.ShortDesc = l.Help.Styles.ShortDesc.Foreground(theme.muted)
+	l.Help.Styles.ShortSeparator = l.Help.Styles.ShortSeparator.Foreground(theme.muted)
+}
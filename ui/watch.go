@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sshgo/i18n"
+	"sshgo/settings"
+	"sshgo/ssh"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// configStampsMsg 定时检查得到的配置文件状态
+type configStampsMsg struct {
+	stamps map[string]ssh.FileStamp
+}
+
+// watchConfig 在 ssh.watch_interval 之后检查一次配置文件、Include 的文件与 known_hosts；间隔为 0 时不检查
+func watchConfig() tea.Cmd {
+	interval := settings.Current().SSH.WatchInterval.Duration
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return configStampsMsg{stamps: ssh.StatFiles(ssh.WatchedFiles())}
+	})
+}
+
+// stampsEqual 比较两次检查的文件状态
+func stampsEqual(a, b map[string]ssh.FileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, sa := range a {
+		sb, ok := b[path]
+		if !ok || sa.Size != sb.Size || !sa.ModTime.Equal(sb.ModTime) {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	registerCommands(paletteCommand{
+		title: i18n.CommandReloadConfig,
+		run: func(m AppModel) (tea.Model, tea.Cmd) {
+			cmd := m.reloadHosts()
+			m.fileStamps = ssh.StatFiles(ssh.WatchedFiles())
+			if !m.isError {
+				m.message = i18n.T(i18n.ConfigReloadedAll)
+			}
+			return m, cmd
+		},
+	})
+}
+
+// handleConfigStamps 文件被其他程序修改时在原处刷新主机列表（过滤、选中项保持不变）
+// 正在进行其他操作时推迟到回到主机列表后，写入前的冲突检测会提示这期间的修改
+func (m AppModel) handleConfigStamps(msg configStampsMsg) (tea.Model, tea.Cmd) {
+	if stampsEqual(m.fileStamps, msg.stamps) {
+		return m, watchConfig()
+	}
+	modified := ssh.ExternallyModified(ssh.WatchedFiles())
+	if len(modified) == 0 {
+		// sshgo 自己写入造成的变化
+		m.fileStamps = msg.stamps
+		return m, watchConfig()
+	}
+	if m.state != stateHostList || len(m.screens) > 0 {
+		return m, watchConfig()
+	}
+
+	selected := ""
+	if h, ok := m.hostList.SelectedItem().(hostItem); ok {
+		selected = h.host.Host
+	}
+	cmd := m.reloadHosts()
+	if selected != "" && m.hostList.FilterState() == list.Unfiltered {
+		m.selectHost(selected)
+	}
+	m.fileStamps = ssh.StatFiles(ssh.WatchedFiles())
+	if !m.isError {
+		m.message = fmt.Sprintf(i18n.T(i18n.ConfigReloaded), strings.Join(modified, ", "))
+	}
+	return m, tea.Batch(cmd, watchConfig())
+}
+
+// writeConflict 写入时发现文件已被外部修改：记录触发写入的按键，合并时在原状态下重新执行
+type writeConflict struct {
+	path  string
+	diff  string
+	state appState
+	msg   tea.KeyMsg
+}
+
+// checkConflict 操作因文件冲突失败时进入合并确认并返回 true
+func (m *AppModel) checkConflict(err error, msg tea.KeyMsg) bool {
+	var conflict *ssh.ConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+	diff, _ := ssh.ExternalChanges(conflict.Path)
+	m.conflict = &writeConflict{path: conflict.Path, diff: diff, state: m.state, msg: msg}
+	m.state = stateConfirmConflict
+	m.message = ""
+	return true
+}
+
+// updateConfirmConflict 合并：接受文件当前内容后重新执行操作；放弃：回到主机列表并重新载入
+func (m AppModel) updateConfirmConflict(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	c := m.conflict
+	switch {
+	case key.Matches(keyMsg, m.keys.Yes):
+		m.conflict = nil
+		if err := ssh.AcceptChanges(c.path); err != nil {
+			m.message = err.Error()
+			m.isError = true
+			m.state = stateHostList
+			return m, nil
+		}
+		m.state = c.state
+		return m.Update(c.msg)
+	case key.Matches(keyMsg, m.keys.No, m.keys.Back):
+		m.conflict = nil
+		m.state = stateHostList
+		m.message = i18n.T(i18n.ConfigConflictAborted)
+		m.isError = false
+		cmd := m.reloadHosts()
+		m.fileStamps = ssh.StatFiles(ssh.WatchedFiles())
+		return m, cmd
+	}
+	return m, nil
+}
+
+// renderConfirmConflict 渲染冲突确认，列出外部修改的内容
+func (m AppModel) renderConfirmConflict() string {
+	var s strings.Builder
+
+	s.WriteString(warningStyle.Render("⚠ " + i18n.T(i18n.ConfigConflictTitle)))
+	s.WriteString("\n\n")
+	s.WriteString(statusStyle.Render(fmt.Sprintf(i18n.T(i18n.ConfigConflictPrompt), m.conflict.path)))
+	s.WriteString("\n\n")
+	s.WriteString(m.renderDiff(m.conflict.diff))
+	s.WriteString("\n\n")
+	s.WriteString(helpStyle.Render(fmt.Sprintf(i18n.T(i18n.ConfigConflictHelp),
+		m.keys.Yes.Help().Key, m.keys.No.Help().Key, m.keys.Back.Help().Key)))
+
+	return s.String()
+}
@@ -0,0 +1,57 @@
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// 应用目录名
+const appName = "sshgo"
+
+// homeDir 获取用户主目录
+func homeDir() string {
+	if runtime.GOOS == "windows" {
+		return os.Getenv("USERPROFILE")
+	}
+	return os.Getenv("HOME")
+}
+
+// StateDir 获取状态目录（历史记录、操作日志等）
+// Linux/macOS: $XDG_STATE_HOME/sshgo，默认 ~/.local/state/sshgo
+// Windows: %LOCALAPPDATA%\sshgo
+func StateDir() string {
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("LOCALAPPDATA"); dir != "" {
+			return filepath.Join(dir, appName)
+		}
+	}
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, appName)
+	}
+	return filepath.Join(homeDir(), ".local", "state", appName)
+}
+
+// ConfigDir 获取配置目录
+// Linux/macOS: $XDG_CONFIG_HOME/sshgo，默认 ~/.config/sshgo
+// Windows: %APPDATA%\sshgo
+func ConfigDir() string {
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("APPDATA"); dir != "" {
+			return filepath.Join(dir, appName)
+		}
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, appName)
+	}
+	return filepath.Join(homeDir(), ".config", appName)
+}
+
+// StatePath 获取状态目录下指定文件的路径，并确保目录存在
+func StatePath(name string) (string, error) {
+	dir := StateDir()
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}